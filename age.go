@@ -0,0 +1,77 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "time"
+
+// Age returns the age, as of Time at, of something (or someone) born at Time
+// birth, expressed in whole years, months and days. Only the calendar dates
+// of birth and at are considered (with at converted to birth's Location) so
+// the time of day has no effect; someone born at 11pm is a year older at
+// 1am on their birthday.
+//
+// The result is the largest such Timespan that, when applied to birth, does
+// not pass at. Its members are never negative; if at is before birth, a zero
+// Timespan is returned.
+//
+// Since years are applied as with From, a birthday on February 29th falls on
+// March 1st in non-leap years; someone born on Feb 29, 2000 is 11 months and
+// 30 days old on Feb 28, 2001 and turns 1 on Mar 1, 2001. Similarly, a month
+// from January 31st is March 3rd (or 2nd) rather than the end of February.
+func Age(birth, at time.Time) *Timespan {
+	loc := birth.Location()
+
+	// Noon is used in place of midnight to steer clear of DST transitions.
+	by, bm, bd := birth.Date()
+	ay, am, ad := at.In(loc).Date()
+	from := time.Date(by, bm, bd, 12, 0, 0, 0, loc)
+	to := time.Date(ay, am, ad, 12, 0, 0, 0, loc)
+
+	if to.Before(from) {
+		return &Timespan{}
+	}
+
+	return Between(from, to).Truncate('D')
+}
+
+// AgeYears returns the number of whole years completed, as of Time at, by
+// something (or someone) born at Time birth; i.e. Age(birth, at).Years. A
+// year is not complete until the anniversary of birth's calendar date is
+// reached, so someone born on Jun 1, 1990 is 28 on May 31, 2019 and 29 on
+// Jun 1. Zero is returned if at is before birth.
+//
+// For a birthday on February 29th, the anniversary in a non-leap year is
+// taken to be March 1st (as with Age); someone born on Feb 29, 2000 is still
+// 0 on Feb 28, 2001 and turns 1 on Mar 1, 2001.
+func AgeYears(birth, at time.Time) int {
+	return Age(birth, at).Years
+}
+
+// AgeNow returns the current age of something born at Time birth; it is
+// shorthand for:
+//
+//	AgeClock(birth, SystemClock)
+func AgeNow(birth time.Time) *Timespan {
+	return AgeClock(birth, SystemClock)
+}
+
+// AgeClock is like AgeNow except that the current time is taken from the
+// given Clock. This allows ages to be computed against a fixed point in
+// time (e.g. in tests).
+func AgeClock(birth time.Time, clock Clock) *Timespan {
+	return Age(birth, clock.Now())
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAge(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		name  string
+		birth time.Time
+		at    time.Time
+		want  *Timespan
+	}{
+		{"31st-feb", date(1990, 1, 31), date(1990, 2, 28), &Timespan{Days: 28}},
+		{"31st-mar-1", date(1990, 1, 31), date(1990, 3, 1), &Timespan{Days: 29}},
+		{"31st-mar-3", date(1990, 1, 31), date(1990, 3, 3), &Timespan{Months: 1}},
+		{"31st-apr-30", date(1990, 1, 31), date(1990, 4, 30), &Timespan{Months: 2, Days: 30}},
+		{"31st-eve", date(1990, 1, 31), date(2020, 1, 30), &Timespan{Years: 29, Months: 11, Days: 30}},
+		{"31st-birthday", date(1990, 1, 31), date(2020, 1, 31), &Timespan{Years: 30}},
+		{"leap-feb-28", date(2000, 2, 29), date(2001, 2, 28), &Timespan{Months: 11, Days: 30}},
+		{"leap-mar-1", date(2000, 2, 29), date(2001, 3, 1), &Timespan{Years: 1}},
+		{"leap-leap", date(2000, 2, 29), date(2004, 2, 29), &Timespan{Years: 4}},
+		{"leap-eve", date(2000, 2, 29), date(2004, 2, 28), &Timespan{Years: 3, Months: 11, Days: 30}},
+		{"borrow", date(1986, 8, 20), date(2019, 1, 2), &Timespan{Years: 32, Months: 4, Days: 13}},
+		{"same-day", date(2019, 6, 1), date(2019, 6, 1), &Timespan{}},
+		{"before-birth", date(2019, 6, 1), date(2019, 5, 1), &Timespan{}},
+		{"time-of-day", time.Date(1990, 5, 10, 23, 0, 0, 0, ny), time.Date(2020, 5, 10, 1, 0, 0, 0, ny), &Timespan{Years: 30}},
+		{"other-zone", time.Date(1990, 5, 10, 12, 0, 0, 0, ny), time.Date(2020, 5, 10, 2, 0, 0, 0, time.UTC), &Timespan{Years: 29, Months: 11, Days: 29}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Age(tc.birth, tc.at)
+			if !got.Equal(tc.want) {
+				t.Errorf("Age(%v, %v) == %v; Wanted %v", tc.birth, tc.at, got, tc.want)
+			}
+
+			if got.Years < 0 || got.Months < 0 || got.Days < 0 || got.Duration != 0 {
+				t.Errorf("Age(%v, %v) == %v; Wanted only non-negative years, months and days", tc.birth, tc.at, got)
+			}
+		})
+	}
+}
+
+func TestAgeYears(t *testing.T) {
+	cases := []struct {
+		birth, at time.Time
+		want      int
+	}{
+		{date(1990, 6, 1), date(2019, 5, 31), 28},
+		{date(1990, 6, 1), date(2019, 6, 1), 29},
+		{date(1990, 6, 1), date(2019, 12, 31), 29},
+		{date(2000, 2, 29), date(2001, 2, 28), 0},
+		{date(2000, 2, 29), date(2001, 3, 1), 1},
+		{date(2000, 2, 29), date(2004, 2, 28), 3},
+		{date(2000, 2, 29), date(2004, 2, 29), 4},
+		{date(2019, 6, 1), date(2019, 6, 1), 0},
+		{date(2019, 6, 1), date(2018, 6, 1), 0},
+	}
+
+	for _, tc := range cases {
+		if got := AgeYears(tc.birth, tc.at); got != tc.want {
+			t.Errorf("AgeYears(%v, %v) == %d; Wanted %d", tc.birth, tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestAgeClock(t *testing.T) {
+	birth := time.Date(2000, 2, 29, 8, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		now  time.Time
+		want *Timespan
+	}{
+		{time.Date(2003, 2, 28, 12, 0, 0, 0, time.UTC), &Timespan{Years: 2, Months: 11, Days: 30}},
+		{time.Date(2003, 3, 1, 12, 0, 0, 0, time.UTC), &Timespan{Years: 3}},
+		{time.Date(2004, 2, 29, 12, 0, 0, 0, time.UTC), &Timespan{Years: 4}},
+	}
+
+	for _, tc := range cases {
+		clock := &fakeClock{now: tc.now}
+		if got := AgeClock(birth, clock); !got.Equal(tc.want) {
+			t.Errorf("AgeClock(%v, %v) == %v; Wanted %v", birth, tc.now, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MarshalBSONValue and UnmarshalBSONValue carry the method signatures of the
+// ValueMarshaler and ValueUnmarshaler interfaces of the MongoDB Go driver
+// (go.mongodb.org/mongo-driver/v2/bson), which identify BSON types by a
+// plain byte; matching them by name means this package needn't import the
+// driver. The tsbson module checks them against the driver itself.
+
+// bsonString is the BSON type code for a UTF-8 string.
+const bsonString = 0x02
+
+// MarshalBSONValue encodes ts as a BSON string holding its String form; the
+// zero Timespan is encoded as an empty string. It has a value receiver (as
+// with MarshalYAML) so that it applies to both Timespan and *Timespan fields.
+func (ts Timespan) MarshalBSONValue() (byte, []byte, error) {
+	s := ts.String()
+
+	// A BSON string is its length (including a trailing NUL) as a
+	// little-endian int32 followed by its bytes and the NUL.
+	data := make([]byte, 4+len(s)+1)
+	binary.LittleEndian.PutUint32(data, uint32(len(s)+1))
+	copy(data[4:], s)
+
+	return bsonString, data, nil
+}
+
+// UnmarshalBSONValue decodes a BSON string into ts using ParseTimespan. An
+// empty string (as produced by MarshalBSONValue for the zero Timespan) is
+// decoded as the zero Timespan. An error is returned for any other BSON type
+// or for a malformed string, in which case ts is left unchanged.
+func (ts *Timespan) UnmarshalBSONValue(t byte, data []byte) error {
+	if t != bsonString {
+		return fmt.Errorf("timespan: cannot decode BSON type 0x%02x into a Timespan", t)
+	}
+
+	if len(data) < 5 {
+		return fmt.Errorf("timespan: BSON string too short")
+	}
+
+	if n := binary.LittleEndian.Uint32(data); int64(n) != int64(len(data)-4) || data[len(data)-1] != 0 {
+		return fmt.Errorf("timespan: malformed BSON string")
+	}
+
+	s := string(data[4 : len(data)-1])
+	if s == "" {
+		*ts = Timespan{}
+		return nil
+	}
+
+	return ParseTimespanInto(s, ts)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// These mirror the ValueMarshaler and ValueUnmarshaler interfaces of the
+// MongoDB driver's bson package.
+type bsonValueMarshaler interface {
+	MarshalBSONValue() (byte, []byte, error)
+}
+
+type bsonValueUnmarshaler interface {
+	UnmarshalBSONValue(byte, []byte) error
+}
+
+var (
+	_ bsonValueMarshaler   = Timespan{}
+	_ bsonValueMarshaler   = &Timespan{}
+	_ bsonValueUnmarshaler = &Timespan{}
+)
+
+func TestMarshalBSONValue(t *testing.T) {
+	typ, data, err := (&Timespan{Days: 30}).MarshalBSONValue()
+	if err != nil {
+		t.Fatalf("MarshalBSONValue() returned error: %v", err)
+	}
+
+	if want := []byte{4, 0, 0, 0, '3', '0', 'D', 0}; typ != bsonString || !bytes.Equal(data, want) {
+		t.Errorf("MarshalBSONValue() == (0x%02x, %v); Wanted (0x%02x, %v)", typ, data, bsonString, want)
+	}
+}
+
+func TestBSONValueRoundTrip(t *testing.T) {
+	spans := []Timespan{
+		{Days: 30},
+		{Years: 1, Months: 6, Duration: 2 * time.Hour},
+		{Years: -1, Months: 2, Weeks: 1},
+		{},
+	}
+
+	for _, ts := range spans {
+		typ, data, err := ts.MarshalBSONValue()
+		if err != nil {
+			t.Errorf("(%v).MarshalBSONValue() returned error: %v", &ts, err)
+			continue
+		}
+
+		back := Timespan{Days: 99}
+		if err := back.UnmarshalBSONValue(typ, data); err != nil {
+			t.Errorf("UnmarshalBSONValue(0x%02x, %v) returned error: %v", typ, data, err)
+		} else if !back.Equal(&ts) {
+			t.Errorf("BSON round-trip mismatch: Got %v; Wanted %v", &back, &ts)
+		}
+	}
+}
+
+func TestUnmarshalBSONValueErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		typ  byte
+		data []byte
+	}{
+		{"int32", 0x10, []byte{1, 0, 0, 0}},
+		{"short", bsonString, []byte{1, 0, 0, 0}},
+		{"bad length", bsonString, []byte{9, 0, 0, 0, '1', 'D', 0}},
+		{"no NUL", bsonString, []byte{3, 0, 0, 0, '1', 'D', 'x'}},
+		{"bad span", bsonString, []byte{5, 0, 0, 0, '1', 'D', '2', 'W', 0}},
+	}
+
+	want := Timespan{Days: 1}
+
+	for _, tc := range cases {
+		got := want
+		if err := got.UnmarshalBSONValue(tc.typ, tc.data); err == nil {
+			t.Errorf("%s: no error from UnmarshalBSONValue", tc.name)
+		}
+
+		if got != want {
+			t.Errorf("%s: UnmarshalBSONValue modified Timespan on error: %v", tc.name, &got)
+		}
+	}
+}
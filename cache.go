@@ -0,0 +1,48 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "sync"
+
+// parseCache maps each string successfully parsed by ParseCached to its
+// (never modified) Timespan value.
+var parseCache sync.Map
+
+// ParseCached is like ParseTimespan except that successful results are
+// memoized so that repeatedly parsing the same string is cheap. It is safe
+// for concurrent use.
+//
+// Each call returns a newly allocated copy of the cached value so callers
+// are free to modify it. Errors are not cached.
+//
+// The cache is never pruned, so ParseCached is intended for a bounded set of
+// strings (e.g. from configuration); parsing arbitrary user input with it
+// will grow the cache without limit.
+func ParseCached(s string) (*Timespan, error) {
+	if v, ok := parseCache.Load(s); ok {
+		ts := v.(Timespan)
+		return &ts, nil
+	}
+
+	ts, err := ParseTimespan(s)
+	if err != nil {
+		return nil, err
+	}
+
+	parseCache.Store(s, *ts)
+	return ts, nil
+}
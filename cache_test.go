@@ -0,0 +1,78 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseCached(t *testing.T) {
+	want := &Timespan{1, 2, 3, 4, 5 * time.Hour}
+
+	for i := 0; i < 3; i++ {
+		got, err := ParseCached("1Y2M3W4D5h")
+		if err != nil {
+			t.Fatalf("ParseCached(%q) failed: %v", "1Y2M3W4D5h", err)
+		}
+
+		if !got.Equal(want) {
+			t.Errorf("ParseCached(%q) == %v; Wanted %v", "1Y2M3W4D5h", got, want)
+		}
+
+		// Modifying the result must not affect later calls
+		got.Years = 100
+	}
+
+	for i := 0; i < 2; i++ {
+		if got, err := ParseCached("1D2W"); err == nil {
+			t.Errorf("ParseCached(%q) == %v; Wanted error", "1D2W", got)
+		}
+	}
+}
+
+func TestParseCachedConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for _, s := range benchSpans {
+				got, err := ParseCached(s)
+				want, _ := ParseTimespan(s)
+				if err != nil || !got.Equal(want) {
+					t.Errorf("ParseCached(%q) == (%v, %v); Wanted %v", s, got, err, want)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkParseCached(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseCached(benchSpans[i%len(benchSpans)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command timespan is a small calculator for Timespan arithmetic.
+//
+// Usage:
+//
+//	timespan [--iso | --verbose] <command> <args>...
+//
+// The commands are:
+//
+//	add <time> <span>       print the time that results from applying span to time
+//	between <time> <time>   print the span from the first time to the second
+//	normalize <span>        print span with months carried into years (see NormalForm)
+//	parse <span>            print the individual fields of span
+//
+// Times are given in RFC 3339 format (e.g. "2023-04-30T12:00:00Z") or as a
+// plain date (e.g. "2023-04-30") which is taken to be midnight UTC. Spans
+// use the syntax accepted by timespan.ParseTimespan. Since flags must appear
+// before the command, a negative span such as "-1Y3M" may be given as an
+// argument without being mistaken for a flag.
+//
+// By default, spans are printed in the compact form accepted by ParseTimespan.
+// The --verbose flag selects the form accepted by ParseUnambiguous and --iso
+// an ISO 8601 duration (with a sign on each negative component).
+//
+// The exit status is 0 on success, 1 if a time or span can't be parsed and
+// 2 for a usage error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"toolman.org/time/timespan/v2"
+)
+
+// Exit codes returned by run.
+const (
+	exitOK    = 0
+	exitParse = 1
+	exitUsage = 2
+)
+
+const usage = `usage: timespan [--iso | --verbose] <command> <args>...
+
+commands:
+  add <time> <span>       apply span to time
+  between <time> <time>   span from the first time to the second
+  normalize <span>        carry months into years
+  parse <span>            show the fields of span
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// A usageError is reported with exitUsage rather than exitParse.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+type command struct {
+	nargs int
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"add":       {2, (*cli).add},
+	"between":   {2, (*cli).between},
+	"normalize": {1, (*cli).normalize},
+	"parse":     {1, (*cli).parse},
+}
+
+type cli struct {
+	out     io.Writer
+	iso     bool
+	verbose bool
+}
+
+// run executes the command line args, writing results to stdout and errors
+// to stderr, and returns the process exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	c := &cli{out: stdout}
+
+	fs := flag.NewFlagSet("timespan", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.BoolVar(&c.iso, "iso", false, "print spans as ISO 8601 durations")
+	fs.BoolVar(&c.verbose, "verbose", false, "print spans with verbose units")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	err := c.dispatch(fs.Args())
+	switch err.(type) {
+	case nil:
+		return exitOK
+	case usageError:
+		fmt.Fprintf(stderr, "timespan: %v\n\n%s", err, usage)
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "timespan: %v\n", err)
+		return exitParse
+	}
+}
+
+func (c *cli) dispatch(args []string) error {
+	if c.iso && c.verbose {
+		return usageError("--iso and --verbose are mutually exclusive")
+	}
+
+	if len(args) == 0 {
+		return usageError("no command given")
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return usageError(fmt.Sprintf("unknown command %q", args[0]))
+	}
+
+	if len(args)-1 != cmd.nargs {
+		return usageError(fmt.Sprintf("%s requires %d argument(s); got %d", args[0], cmd.nargs, len(args)-1))
+	}
+
+	return cmd.run(c, args[1:])
+}
+
+func (c *cli) add(args []string) error {
+	t, err := parseTime(args[0])
+	if err != nil {
+		return err
+	}
+
+	ts, err := timespan.ParseTimespan(args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, ts.From(t).Format(time.RFC3339Nano))
+	return nil
+}
+
+func (c *cli) between(args []string) error {
+	from, err := parseTime(args[0])
+	if err != nil {
+		return err
+	}
+
+	to, err := parseTime(args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, c.format(timespan.Between(from, to)))
+	return nil
+}
+
+func (c *cli) normalize(args []string) error {
+	ts, err := timespan.ParseTimespan(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, c.format(ts.NormalForm()))
+	return nil
+}
+
+func (c *cli) parse(args []string) error {
+	ts, err := timespan.ParseTimespan(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "Years:    %d\nMonths:   %d\nWeeks:    %d\nDays:     %d\nDuration: %v\nSpan:     %s\n",
+		ts.Years, ts.Months, ts.Weeks, ts.Days, ts.Duration, c.format(ts))
+	return nil
+}
+
+// format renders ts according to the --iso and --verbose flags.
+func (c *cli) format(ts *timespan.Timespan) string {
+	switch {
+	case c.iso:
+		return isoDuration(ts)
+	case ts.IsZero():
+		return "0s"
+	case c.verbose:
+		return ts.StringWith(timespan.RenderOptions{TrimZeroUnits: true, Verbose: true})
+	default:
+		return ts.String()
+	}
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse time %q: want RFC 3339 or YYYY-MM-DD", s)
+}
+
+// isoDuration renders ts as an ISO 8601 duration such as "P1Y2M3DT4H5M6.5S".
+// Negative components carry their own sign, e.g. "P-1Y2M". Weeks are rendered
+// as days since ISO 8601 doesn't allow "W" alongside other units.
+func isoDuration(ts *timespan.Timespan) string {
+	var sb strings.Builder
+	sb.WriteString("P")
+
+	for _, c := range []struct {
+		v    int
+		unit string
+	}{{ts.Years, "Y"}, {ts.Months, "M"}, {7*ts.Weeks + ts.Days, "D"}} {
+		if c.v != 0 {
+			sb.WriteString(strconv.Itoa(c.v) + c.unit)
+		}
+	}
+
+	d := ts.Duration
+	if d == 0 {
+		if sb.Len() == 1 {
+			return "PT0S"
+		}
+		return sb.String()
+	}
+
+	sb.WriteString("T")
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+
+	// Work with the magnitude as a uint64 to cope with math.MinInt64.
+	u := uint64(d)
+	if d < 0 {
+		u = -u
+	}
+
+	if h := u / uint64(time.Hour); h != 0 {
+		fmt.Fprintf(&sb, "%s%dH", sign, h)
+	}
+
+	if m := u % uint64(time.Hour) / uint64(time.Minute); m != 0 {
+		fmt.Fprintf(&sb, "%s%dM", sign, m)
+	}
+
+	if ns := u % uint64(time.Minute); ns != 0 {
+		secs := strconv.FormatUint(ns/uint64(time.Second), 10)
+		if frac := ns % uint64(time.Second); frac != 0 {
+			secs += strings.TrimRight(fmt.Sprintf(".%09d", frac), "0")
+		}
+		fmt.Fprintf(&sb, "%s%sS", sign, secs)
+	}
+
+	return sb.String()
+}
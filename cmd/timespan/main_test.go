@@ -0,0 +1,93 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"toolman.org/time/timespan/v2"
+)
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		want string
+		code int
+	}{
+		{"add", []string{"add", "2023-04-30", "9M3D"}, "2024-02-02T00:00:00Z\n", exitOK},
+		{"add-rfc3339", []string{"add", "2023-04-30T12:00:00-04:00", "-1D2h"}, "2023-04-29T14:00:00-04:00\n", exitOK},
+		{"between", []string{"between", "2023-04-30", "2024-02-02T01:30:00Z"}, "9M3D1h30m0s\n", exitOK},
+		{"between-negative", []string{"between", "2024-02-02", "2023-04-30"}, "-9M-2D\n", exitOK},
+		{"between-same", []string{"between", "2024-02-02", "2024-02-02"}, "0s\n", exitOK},
+		{"normalize", []string{"normalize", "1Y-3M"}, "9M\n", exitOK},
+		{"normalize-carry", []string{"normalize", "27M"}, "2Y3M\n", exitOK},
+		{"verbose", []string{"--verbose", "normalize", "27M4D90m"}, "2yr 3mo 4d 1h 30min\n", exitOK},
+		{"iso", []string{"--iso", "normalize", "27M4D90m"}, "P2Y3M4DT1H30M\n", exitOK},
+		{"parse", []string{"parse", "1Y2M3W4D5h"}, "Years:    1\nMonths:   2\nWeeks:    3\nDays:     4\nDuration: 5h0m0s\nSpan:     1Y2M3W4D5h0m0s\n", exitOK},
+
+		{"bad-span", []string{"parse", "1D2W"}, "", exitParse},
+		{"bad-time", []string{"add", "yesterday", "1D"}, "", exitParse},
+		{"no-command", nil, "", exitUsage},
+		{"unknown-command", []string{"frob", "1D"}, "", exitUsage},
+		{"arg-count", []string{"add", "2023-04-30"}, "", exitUsage},
+		{"bad-flag", []string{"--bogus", "parse", "1D"}, "", exitUsage},
+		{"both-flags", []string{"--iso", "--verbose", "parse", "1D"}, "", exitUsage},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			if code := run(tc.args, &stdout, &stderr); code != tc.code {
+				t.Errorf("run(%q) == %d; Wanted %d (stderr: %q)", tc.args, code, tc.code, stderr.String())
+			}
+
+			if got := stdout.String(); got != tc.want {
+				t.Errorf("run(%q) output == %q; Wanted %q", tc.args, got, tc.want)
+			}
+
+			if tc.code != exitOK && !strings.HasPrefix(stderr.String(), "timespan: ") && tc.name != "bad-flag" {
+				t.Errorf("run(%q) stderr == %q; Wanted an error message", tc.args, stderr.String())
+			}
+		})
+	}
+}
+
+func TestISODuration(t *testing.T) {
+	cases := []struct {
+		ts   *timespan.Timespan
+		want string
+	}{
+		{&timespan.Timespan{}, "PT0S"},
+		{&timespan.Timespan{Years: 1, Months: 2, Days: 3}, "P1Y2M3D"},
+		{&timespan.Timespan{Duration: 1500 * time.Millisecond}, "PT1.5S"},
+		{&timespan.Timespan{Days: 1, Duration: 2*time.Hour + 5*time.Second}, "P1DT2H5S"},
+		{&timespan.Timespan{Years: -1, Months: 2, Duration: -90 * time.Minute}, "P-1Y2MT-1H-30M"},
+		{&timespan.Timespan{Duration: math.MinInt64}, "PT-2562047H-47M-16.854775808S"},
+	}
+
+	for _, tc := range cases {
+		if got := isoDuration(tc.ts); got != tc.want {
+			t.Errorf("isoDuration(%v) == %q; Wanted %q", tc.ts, got, tc.want)
+		}
+	}
+}
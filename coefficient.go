@@ -18,43 +18,39 @@ package timespan
 
 import "strconv"
 
-type coefficient []rune
+// A coefficient is the run of the input string holding an optional sign
+// followed by digits. It is a substring of the input (rather than a copy)
+// so that parsing a Timespan needn't allocate.
+type coefficient string
 
-func newCoefficient() coefficient {
-	return coefficient(make([]rune, 0))
-}
-
-// String is to implement the fmt.Stringer interface
-func (c *coefficient) String() string {
-	return string(*c)
-}
-
-func (c *coefficient) appendRune(r rune) (bool, *timespanErr) {
+// accepts reports whether r may extend the coefficient. A sign is only
+// acceptable as the first character; anything else yields MisplacedSignErr.
+func (c coefficient) accepts(r rune) (bool, *ParseError) {
 	if r == '-' || r == '+' {
-		if len(*c) > 0 {
-			return false, timespanError(misplacedSignErr, "misplaced '%c' in coefficient %q", r, c)
+		if len(c) > 0 {
+			return false, timespanError(MisplacedSignErr, "misplaced '%c' in coefficient %q", r, string(c))
 		}
-		*c = append(*c, r)
 		return true, nil
 	}
 
 	if r >= '0' && r <= '9' {
-		*c = append(*c, r)
 		return true, nil
 	}
 
 	return false, nil
 }
 
-func (c *coefficient) value(sign int) (int, *timespanErr) {
-	if len(*c) < 1 {
-		return 0, timespanError(missingCoefErr, "missing coefficient")
+func (c coefficient) value(sign int) (int, *ParseError) {
+	if len(c) < 1 {
+		return 0, timespanError(MissingCoefErr, "missing coefficient")
 	}
 
-	cs := string(*c)
+	cs := string(c)
 	cv, err := strconv.Atoi(cs)
-	if err != nil {
-		return 0, timespanError(unparseableCoefErr, "unparseable coefficient: %q", cs)
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return 0, timespanError(CoefOverflowErr, "coefficient %s overflows int", cs)
+	} else if err != nil {
+		return 0, timespanError(UnparseableCoefErr, "unparseable coefficient: %q", cs)
 	}
 
 	// If a) the sign override is negative and
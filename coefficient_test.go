@@ -22,43 +22,44 @@ import (
 )
 
 func TestCoefficientEmptyAppendNonDigit(t *testing.T) {
-	coef := newCoefficient()
+	var coef coefficient
 
 	// Append a non-digit to an empty coefficient
 	// Expect: false, nil
-	if ok, err := coef.appendRune('x'); err != nil {
+	if ok, err := coef.accepts('x'); err != nil {
 		t.Errorf("Error appending 'x' to coefficient %q", coef)
 	} else if ok {
-		t.Error("appendRune erroneously accepted 'x' as a valid digit")
+		t.Error("accepts erroneously accepted 'x' as a valid digit")
 	}
 }
 
 func TestCoefficientEmptyValue(t *testing.T) {
-	coef := newCoefficient()
+	var coef coefficient
 
 	// Retrieve value from empty coefficient
-	// Expect: _, missingCoefErr
+	// Expect: _, MissingCoefErr
 	_, err := coef.value(1)
 	if err == nil {
-		t.Errorf("No error while retreiving value from empty coefficient: Wanted %v", missingCoefErr)
-	} else if err != nil && err.errorType != missingCoefErr {
-		t.Errorf("Incorrect error retreiving value from empty coefficient: Got %v; Wanted %v", err, missingCoefErr)
+		t.Errorf("No error while retreiving value from empty coefficient: Wanted %v", MissingCoefErr)
+	} else if err != nil && err.errorType != MissingCoefErr {
+		t.Errorf("Incorrect error retreiving value from empty coefficient: Got %v; Wanted %v", err, MissingCoefErr)
 	}
 }
 
 func TestCoefficientValidDigits(t *testing.T) {
 	want := 9876543210
 	chars := strconv.Itoa(want)
-	coef := newCoefficient()
+	var coef coefficient
 
 	// Append each valid digit in turn
 	// Expect: true, nil (for each digit)
 	for _, r := range chars {
-		if ok, err := coef.appendRune(r); err != nil {
+		if ok, err := coef.accepts(r); err != nil {
 			t.Errorf("Error appending '%c' to coefficient %q", r, coef)
 		} else if !ok {
-			t.Errorf("appendRune failed to accept valid character '%c'", r)
+			t.Errorf("accepts failed to accept valid character '%c'", r)
 		}
+		coef += coefficient(string(r))
 	}
 }
 
@@ -81,10 +82,10 @@ func TestCoefficientNonDigit(t *testing.T) {
 
 	// Append non-digit character to populated coefficient
 	// Expect: false, nil
-	if ok, err := coef.appendRune(ndc); err != nil {
+	if ok, err := coef.accepts(ndc); err != nil {
 		t.Errorf("Error appending '%c' to coefficient %q", ndc, coef)
 	} else if ok {
-		t.Errorf("coefficient.appendRune() erroneously accepted '%c' as a valid digit", ndc)
+		t.Errorf("coefficient.accepts() erroneously accepted '%c' as a valid digit", ndc)
 	}
 
 }
@@ -93,28 +94,28 @@ func TestCoefficientBadSign(t *testing.T) {
 	coef := coefficient("123")
 
 	// Append '-' and '+' chars to populated coefficient
-	// Expect: false, misplacedSignErr
+	// Expect: false, MisplacedSignErr
 	for _, c := range []rune{'-', '+'} {
-		if ok, err := coef.appendRune(c); err != nil {
-			if err.errorType != misplacedSignErr {
-				t.Errorf("Incorrect error returned while appending '%c' to non-empty coefficient: Got %v; Wanted %v", c, err, misplacedSignErr)
+		if ok, err := coef.accepts(c); err != nil {
+			if err.errorType != MisplacedSignErr {
+				t.Errorf("Incorrect error returned while appending '%c' to non-empty coefficient: Got %v; Wanted %v", c, err, MisplacedSignErr)
 			}
 		} else if ok {
-			t.Errorf("appendRune erroneously accepted '%c' as a valid digit", c)
+			t.Errorf("accepts erroneously accepted '%c' as a valid digit", c)
 		}
 	}
 }
 
 func TestCoefficientGoodSign(t *testing.T) {
-	coef := newCoefficient()
+	var coef coefficient
 
-	if ok, err := coef.appendRune('-'); err != nil {
+	if ok, err := coef.accepts('-'); err != nil {
 		t.Errorf("Error appending '-' to empty coefficient: %v", err)
 	} else if !ok {
-		t.Errorf("appendRune failed to accept '-' for empty coefficient")
+		t.Errorf("accepts failed to accept '-' for empty coefficient")
 	}
 
-	coef = append(coef, '1', '2')
+	coef = "-12"
 
 	if v, err := coef.value(1); err != nil {
 		t.Errorf("Error acquiring value of coefficient %q: %v", coef, err)
@@ -0,0 +1,43 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimespan returns a copy of ctx whose deadline is the result of applying
+// ts to the current time; it is shorthand for:
+//
+//	WithTimespanAt(ctx, ts, time.Now())
+func WithTimespan(ctx context.Context, ts *Timespan) (context.Context, context.CancelFunc) {
+	return WithTimespanAt(ctx, ts, time.Now())
+}
+
+// WithTimespanAt returns a copy of ctx whose deadline is ts.From(t), as with
+// context.WithDeadline. If ts resolves to a zero or negative span at t (or t
+// is far enough in the past), the deadline has already passed and the
+// returned context is done immediately. As with context.WithDeadline, if
+// ctx already has an earlier deadline, that deadline is kept.
+//
+// Canceling the returned context releases resources associated with it, so
+// code should call cancel as soon as the operations running in the context
+// complete.
+func WithTimespanAt(ctx context.Context, ts *Timespan, t time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, ts.From(t))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithTimespanAt(t *testing.T) {
+	// One month from Jan 31 crosses into March.
+	at := time.Now().AddDate(1, 0, 0)
+	at = time.Date(at.Year(), time.January, 31, 12, 0, 0, 0, time.UTC)
+	ts := &Timespan{Months: 1}
+
+	ctx, cancel := WithTimespanAt(context.Background(), ts, at)
+	defer cancel()
+
+	got, ok := ctx.Deadline()
+	if want := ts.From(at); !ok || !got.Equal(want) {
+		t.Errorf("WithTimespanAt(%v, %v) deadline == (%v, %v); Wanted (%v, true)", ts, at, got, ok, want)
+	}
+
+	if got.Month() != time.March {
+		t.Errorf("WithTimespanAt(%v, %v) deadline == %v; Wanted a date in March", ts, at, got)
+	}
+
+	if err := ctx.Err(); err != nil {
+		t.Errorf("WithTimespanAt(%v, %v) context is already done: %v", ts, at, err)
+	}
+}
+
+func TestWithTimespanExpired(t *testing.T) {
+	for _, ts := range []*Timespan{nil, {}, {Days: -1}} {
+		ctx, cancel := WithTimespan(context.Background(), ts)
+
+		select {
+		case <-ctx.Done():
+			if err := ctx.Err(); err != context.DeadlineExceeded {
+				t.Errorf("WithTimespan(%v) context error == %v; Wanted %v", ts, err, context.DeadlineExceeded)
+			}
+		case <-time.After(5 * time.Second):
+			t.Errorf("WithTimespan(%v) context is not done", ts)
+		}
+
+		cancel()
+	}
+}
+
+func TestWithTimespanParentDeadline(t *testing.T) {
+	parent, pcancel := context.WithTimeout(context.Background(), time.Hour)
+	defer pcancel()
+
+	ctx, cancel := WithTimespan(parent, &Timespan{Days: 1})
+	defer cancel()
+
+	want, _ := parent.Deadline()
+	if got, _ := ctx.Deadline(); !got.Equal(want) {
+		t.Errorf("WithTimespan deadline == %v; Wanted parent deadline %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+// MarshalCSV and UnmarshalCSV carry the method signatures that gocsv
+// (github.com/gocarina/gocsv) looks for on each field it encodes or decodes;
+// matching them by name means this package needn't import gocsv. The tscsv
+// module checks them against gocsv itself.
+
+// MarshalCSV renders ts as a CSV cell using its String method; the zero (or
+// nil) Timespan is rendered as an empty cell. Like String, it has a pointer
+// receiver; gocsv takes the address of each field it encodes so it applies
+// to both Timespan and *Timespan fields.
+func (ts *Timespan) MarshalCSV() (string, error) {
+	if ts == nil {
+		return "", nil
+	}
+
+	return ts.String(), nil
+}
+
+// UnmarshalCSV decodes a CSV cell into ts using ParseTimespan. An empty cell
+// (as produced by MarshalCSV for the zero Timespan) is decoded as the zero
+// Timespan. If csv can't be parsed, ts is left unchanged.
+func (ts *Timespan) UnmarshalCSV(csv string) error {
+	if csv == "" {
+		*ts = Timespan{}
+		return nil
+	}
+
+	return ParseTimespanInto(csv, ts)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+// These mirror the TypeMarshaller and TypeUnmarshaller interfaces that gocsv
+// checks for on each field.
+type csvMarshaler interface {
+	MarshalCSV() (string, error)
+}
+
+type csvUnmarshaler interface {
+	UnmarshalCSV(string) error
+}
+
+var (
+	_ csvMarshaler   = &Timespan{}
+	_ csvUnmarshaler = &Timespan{}
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	spans := []Timespan{
+		{Days: 30},
+		{Years: 1, Months: 6, Duration: 2 * time.Hour},
+		{Years: -1, Months: 2, Weeks: 1},
+		{},
+	}
+
+	// Encode each span as a CSV cell and decode it again, as gocsv would.
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, ts := range spans {
+		cell, err := ts.MarshalCSV()
+		if err != nil {
+			t.Fatalf("(%v).MarshalCSV() returned error: %v", &ts, err)
+		}
+
+		if err := w.Write([]string{"row", cell}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, rec := range records {
+		back := Timespan{Days: 99}
+		if err := back.UnmarshalCSV(rec[1]); err != nil {
+			t.Errorf("UnmarshalCSV(%q) returned error: %v", rec[1], err)
+		} else if !back.Equal(&spans[i]) {
+			t.Errorf("CSV round-trip mismatch for %q: Got %v; Wanted %v", rec[1], &back, &spans[i])
+		}
+	}
+}
+
+func TestUnmarshalCSVError(t *testing.T) {
+	want := Timespan{Days: 1}
+
+	got := want
+	if err := got.UnmarshalCSV("1D2W"); err == nil {
+		t.Errorf("UnmarshalCSV(%q) returned no error", "1D2W")
+	}
+
+	if got != want {
+		t.Errorf("UnmarshalCSV modified its receiver on error: Got %v; Wanted %v", &got, &want)
+	}
+}
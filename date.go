@@ -0,0 +1,54 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "time"
+
+// ApplyToDate applies the calendar members of ts (its Years, Months, Weeks and
+// Days) to the civil date given by year, month and day and returns the
+// resulting date. It follows the same rules as From, normalizing any overflow
+// past the end of a month into the following month; e.g. Jan 31 + "1M" is
+// Mar 3 (in a non-leap year). Use ApplyToDateClamped for end-of-month
+// clamping as with FromClamped.
+//
+// Since a date has no time of day, the Duration of ts is ignored entirely;
+// it is never rounded into a number of days. No time zone is involved so the
+// result is unaffected by DST. The input date may itself be unnormalized
+// (e.g. Feb 30), as with time.Date. A nil Timespan is treated as a zero span.
+func (ts *Timespan) ApplyToDate(
+	year int, month time.Month, day int,
+) (int, time.Month, int) {
+	v := ts.orZero()
+	return civilDate(year, month, day).AddDate(v.Years, v.Months, v.days()).Date()
+}
+
+// ApplyToDateClamped is like ApplyToDate except that, as with FromClamped,
+// the day of the month is clamped to the last day of the target month after
+// applying Years and Months; e.g. Jan 31 + "1M" is Feb 28 (or 29).
+func (ts *Timespan) ApplyToDateClamped(
+	year int, month time.Month, day int,
+) (int, time.Month, int) {
+	v := ts.orZero()
+	d := addMonthsClamped(civilDate(year, month, day), 12*v.Years+v.Months)
+	return d.AddDate(0, 0, v.days()).Date()
+}
+
+// civilDate returns the given date as a time.Time in UTC, which has no DST
+// transitions to interfere with date arithmetic.
+func civilDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+type civil struct {
+	y int
+	m time.Month
+	d int
+}
+
+func TestTimespanApplyToDate(t *testing.T) {
+	cases := []struct {
+		name    string
+		ts      *Timespan
+		date    civil
+		want    civil
+		clamped civil
+	}{
+		{"jan-31", &Timespan{Months: 1}, civil{2019, 1, 31}, civil{2019, 3, 3}, civil{2019, 2, 28}},
+		{"jan-31-leap", &Timespan{Months: 1}, civil{2020, 1, 31}, civil{2020, 3, 2}, civil{2020, 2, 29}},
+		{"leap-day", &Timespan{Years: 1}, civil{2020, 2, 29}, civil{2021, 3, 1}, civil{2021, 2, 28}},
+		{"leap-to-leap", &Timespan{Years: 4}, civil{2020, 2, 29}, civil{2024, 2, 29}, civil{2024, 2, 29}},
+		{"days", &Timespan{Days: 45}, civil{2019, 12, 20}, civil{2020, 2, 3}, civil{2020, 2, 3}},
+		{"clamp-then-days", &Timespan{Months: 1, Days: 1}, civil{2019, 1, 31}, civil{2019, 3, 4}, civil{2019, 3, 1}},
+		{"negative", &Timespan{Months: -1}, civil{2019, 3, 31}, civil{2019, 3, 3}, civil{2019, 2, 28}},
+		{"negative-days", &Timespan{Years: -1, Days: -1}, civil{2019, 1, 1}, civil{2017, 12, 31}, civil{2017, 12, 31}},
+		{"duration-ignored", &Timespan{Days: 1, Duration: 47 * time.Hour}, civil{2019, 3, 9}, civil{2019, 3, 10}, civil{2019, 3, 10}},
+		{"negative-duration-ignored", &Timespan{Duration: -time.Nanosecond}, civil{2019, 1, 1}, civil{2019, 1, 1}, civil{2019, 1, 1}},
+		{"nil", nil, civil{2019, 1, 1}, civil{2019, 1, 1}, civil{2019, 1, 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			y, m, d := tc.ts.ApplyToDate(tc.date.y, tc.date.m, tc.date.d)
+			if got := (civil{y, m, d}); got != tc.want {
+				t.Errorf("(%v).ApplyToDate(%v) == %v; Wanted %v", tc.ts, tc.date, got, tc.want)
+			}
+
+			y, m, d = tc.ts.ApplyToDateClamped(tc.date.y, tc.date.m, tc.date.d)
+			if got := (civil{y, m, d}); got != tc.clamped {
+				t.Errorf("(%v).ApplyToDateClamped(%v) == %v; Wanted %v", tc.ts, tc.date, got, tc.clamped)
+			}
+		})
+	}
+}
+
+func TestTimespanApplyToDateMatchesFrom(t *testing.T) {
+	ts := &Timespan{Years: 1, Months: 5, Days: 17}
+
+	for d := civilDate(2019, 1, 1); d.Year() < 2021; d = d.AddDate(0, 0, 1) {
+		y, m, dd := ts.ApplyToDate(d.Date())
+		if wy, wm, wd := ts.From(d).Date(); y != wy || m != wm || dd != wd {
+			t.Fatalf("(%v).ApplyToDate(%v) == %d-%d-%d; Wanted %d-%d-%d", ts, d, y, m, dd, wy, wm, wd)
+		}
+
+		y, m, dd = ts.ApplyToDateClamped(d.Date())
+		if wy, wm, wd := ts.FromClamped(d).Date(); y != wy || m != wm || dd != wd {
+			t.Fatalf("(%v).ApplyToDateClamped(%v) == %d-%d-%d; Wanted %d-%d-%d", ts, d, y, m, dd, wy, wm, wd)
+		}
+	}
+}
@@ -16,39 +16,53 @@ limitations under the License.
 
 package timespan
 
-//go:generate stringer -type=errType
+//go:generate stringer -type=ErrType
 
 import "fmt"
 
-type errType int
+// ErrType classifies the kind of failure described by a ParseError.
+type ErrType int
 
+// The ErrType values that may be reported by a ParseError.
 const (
-	noErr errType = iota
-	misplacedSignErr
-	missingCoefErr
-	unparseableCoefErr
-	unrecognizedMagErr
-	magnOrderUnkownErr
-	magnRestatedErr
-	magnOutOfOrderError
-	badDurationErr
+	NoErr ErrType = iota
+	MisplacedSignErr
+	MissingCoefErr
+	UnparseableCoefErr
+	UnrecognizedMagErr
+	MagnOrderUnknownErr // no longer reported
+	MagnRestatedErr
+	MagnOutOfOrderErr
+	BadDurationErr
+	EmptyInputErr
+	BadRangeErr
+	RangeOrderErr
+	CoefOverflowErr
+	InvalidUTF8Err
+	OutOfBoundsErr
+	BadTimeOfDayErr
+	UnknownKeywordErr
+	BadScheduleErr
 )
 
-type timespanErr struct {
-	errorType errType
+// A ParseError is returned by ParseTimespan when it is unable to parse its
+// input string. Its Type and Input methods describe the kind of failure
+// that occurred and the string that triggered it.
+type ParseError struct {
+	errorType ErrType
 	tsString  string
 	message   string
 }
 
-func timespanError(etype errType, mesg string, args ...interface{}) *timespanErr {
-	return &timespanErr{
+func timespanError(etype ErrType, mesg string, args ...interface{}) *ParseError {
+	return &ParseError{
 		errorType: etype,
 		message:   fmt.Sprintf(mesg, args...),
 	}
 }
 
 // Error is part of the error interface
-func (te *timespanErr) Error() string {
+func (te *ParseError) Error() string {
 	if te.tsString == "" {
 		return te.message
 	}
@@ -56,7 +70,17 @@ func (te *timespanErr) Error() string {
 	return fmt.Sprintf("parsing Timespan %q: %s", te.tsString, te.message)
 }
 
-func (te *timespanErr) withTimespan(ts string) *timespanErr {
+// Type returns the ErrType classifying this parsing failure.
+func (te *ParseError) Type() ErrType {
+	return te.errorType
+}
+
+// Input returns the string whose parsing caused this error.
+func (te *ParseError) Input() string {
+	return te.tsString
+}
+
+func (te *ParseError) withTimespan(ts string) *ParseError {
 	te.tsString = ts
 	return te
 }
@@ -0,0 +1,63 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "flag"
+
+// Set parses s using ParseTimespan and stores the result in ts. Along with
+// String, this allows a *Timespan to be used as a flag.Value.
+//
+// If s cannot be parsed, ts is left unchanged and the parsing error is
+// returned (to which the flag package will prepend the flag's name).
+//
+// A zero Timespan renders as the empty string, so a flag defined with
+// flag.Var on a zero Timespan reports no default value in its usage message.
+func (ts *Timespan) Set(s string) error {
+	return ParseTimespanInto(s, ts)
+}
+
+// Type returns the name of the flag value type, "timespan". This satisfies
+// the pflag.Value interface (used by github.com/spf13/pflag and cobra), which
+// extends flag.Value with this method for use in help output. The tspflag
+// module exercises this against pflag itself.
+func (ts *Timespan) Type() string {
+	return "timespan"
+}
+
+// TimespanFlag defines a Timespan flag on fs with the specified name, default
+// value, and usage string. The return value is the address of a Timespan
+// variable that stores the value of the flag. If fs is nil, the flag is
+// defined on flag.CommandLine.
+//
+// TimespanFlag panics if defaultValue is neither empty nor parseable by
+// ParseTimespan.
+func TimespanFlag(fs *flag.FlagSet, name, defaultValue, usage string) *Timespan {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	ts := new(Timespan)
+	if defaultValue != "" {
+		if err := ts.Set(defaultValue); err != nil {
+			panic("timespan: bad default for flag " + name + ": " + err.Error())
+		}
+	}
+
+	fs.Var(ts, name, usage)
+
+	return ts
+}
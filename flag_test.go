@@ -0,0 +1,127 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Ensure *Timespan implements flag.Value
+var _ flag.Value = (*Timespan)(nil)
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	return fs
+}
+
+func TestTimespanFlag(t *testing.T) {
+	fs := newTestFlagSet()
+	ttl := TimespanFlag(fs, "ttl", "1D", "time to live")
+
+	if want := (&Timespan{Days: 1}); !ttl.Equal(want) {
+		t.Errorf("TimespanFlag default mismatch: Got %+v; Wanted %+v", ttl, want)
+	}
+
+	if err := fs.Parse([]string{"--ttl=1Y6M2h"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&Timespan{Years: 1, Months: 6, Duration: 2 * time.Hour}); !ttl.Equal(want) {
+		t.Errorf("TimespanFlag value mismatch: Got %+v; Wanted %+v", ttl, want)
+	}
+
+	if got := fs.Lookup("ttl").Value.String(); got != "1Y6M2h0m0s" {
+		t.Errorf("flag value rendered as %q; Wanted %q", got, "1Y6M2h0m0s")
+	}
+}
+
+func TestTimespanFlagBadValue(t *testing.T) {
+	fs := newTestFlagSet()
+	ttl := TimespanFlag(fs, "ttl", "", "time to live")
+
+	err := fs.Parse([]string{"-ttl", "1D2W"})
+	if err == nil {
+		t.Fatal("No error parsing invalid flag value")
+	}
+
+	if !strings.Contains(err.Error(), "-ttl") {
+		t.Errorf("flag error %q does not identify the flag", err)
+	}
+
+	if !ttl.IsZero() {
+		t.Errorf("invalid flag value modified Timespan: %+v", ttl)
+	}
+}
+
+func TestTimespanFlagBadDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("TimespanFlag failed to panic on an invalid default value")
+		}
+	}()
+
+	TimespanFlag(newTestFlagSet(), "ttl", "bogus", "time to live")
+}
+
+func TestTimespanFlagVar(t *testing.T) {
+	var ttl Timespan
+
+	fs := newTestFlagSet()
+	fs.Var(&ttl, "ttl", "time to live")
+
+	var usage strings.Builder
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+
+	if strings.Contains(usage.String(), "default") {
+		t.Errorf("zero Timespan flag reported a default: %q", usage.String())
+	}
+
+	if got := fs.Lookup("ttl").DefValue; got != "" {
+		t.Errorf("zero flag default rendered as %q; Wanted %q", got, "")
+	}
+
+	if err := fs.Parse([]string{"-ttl", "2W3h"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (Timespan{Weeks: 2, Duration: 3 * time.Hour}); ttl != want {
+		t.Errorf("flag.Var value mismatch: Got %+v; Wanted %+v", ttl, want)
+	}
+}
+
+// pflagValue mirrors the pflag.Value interface from github.com/spf13/pflag.
+type pflagValue interface {
+	flag.Value
+	Type() string
+}
+
+// Ensure *Timespan implements pflag.Value
+var _ pflagValue = (*Timespan)(nil)
+
+func TestTimespanType(t *testing.T) {
+	var v pflagValue = new(Timespan)
+
+	if got, want := v.Type(), "timespan"; got != want {
+		t.Errorf("Type() == %q; Wanted %q", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzParseTimespan(f *testing.F) {
+	for _, s := range []string{
+		// Documented examples
+		"1Y6M", "18M", "3W", "1Y2M3W4D5h6m7s89ms", "-1Y2M", "-1Y+2M", "3W-1W",
+
+		// Known-bad strings from the tests
+		"Y", "1h2D", "1D2W", "4W1-D", "4W1+2D", "18h9", "1D5", "1D+-5h", "",
+		"+", "-", "99999999999999999999Y", "1Y2\xffD",
+
+		// Pathological inputs
+		"1D" + strings.Repeat("9", 1000) + "h", strings.Repeat("+", 100),
+	} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		ts, err := ParseTimespan(s)
+		if err != nil {
+			if ts != nil {
+				t.Errorf("ParseTimespan(%q) == (%v, %v); Wanted nil on error", s, ts, err)
+			}
+			if _, ok := err.(*ParseError); !ok {
+				t.Errorf("ParseTimespan(%q) error == %#v; Wanted a *ParseError", s, err)
+			}
+			if !utf8.ValidString(s) && errType(err) != InvalidUTF8Err {
+				t.Errorf("ParseTimespan(%q) error == %v; Wanted %v", s, err, InvalidUTF8Err)
+			}
+			return
+		}
+
+		if ts.IsZero() {
+			return
+		}
+
+		// Any successful parse must survive a round-trip through String
+		str := ts.String()
+		got, err := ParseTimespan(str)
+		if err != nil || !got.Equal(ts) {
+			t.Errorf("ParseTimespan(%q) == %#v; which renders as %q and reparses to (%#v, %v)", s, ts, str, got, err)
+		}
+	})
+}
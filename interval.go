@@ -0,0 +1,96 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "time"
+
+// An Interval is the window of time beginning at Start and extending for the
+// Timespan Span, as evaluated from Start. Intervals are half-open: Start is
+// included, End is not.
+//
+// If Span resolves to a point before Start, the Interval covers [End, Start)
+// instead. If Span resolves to zero (including a nil Span), the Interval is
+// empty and contains no time at all.
+type Interval struct {
+	Start time.Time
+	Span  *Timespan
+}
+
+// End returns the point in time at which i ends, which is Span applied to
+// Start. For a negative Span, End is before Start.
+func (i Interval) End() time.Time {
+	return i.Span.From(i.Start)
+}
+
+// IsEmpty returns true if i covers no time at all.
+func (i Interval) IsEmpty() bool {
+	lo, hi := i.bounds()
+	return !lo.Before(hi)
+}
+
+// Contains returns true if t falls within i; that is, on or after the
+// earlier of Start and End but before the later of the two.
+func (i Interval) Contains(t time.Time) bool {
+	lo, hi := i.bounds()
+	return !t.Before(lo) && t.Before(hi)
+}
+
+// Overlaps returns true if i and o share any point in time. An empty
+// Interval overlaps nothing.
+func (i Interval) Overlaps(o Interval) bool {
+	_, ok := i.Intersect(o)
+	return ok
+}
+
+// Intersect returns the Interval covered by both i and o, and true if that
+// Interval is not empty. Since the intersection may not correspond to any
+// whole number of calendar units, the Span of the returned Interval has
+// only its Duration set and is always non-negative.
+func (i Interval) Intersect(o Interval) (Interval, bool) {
+	ilo, ihi := i.bounds()
+	olo, ohi := o.bounds()
+
+	lo, hi := ilo, ihi
+	if olo.After(lo) {
+		lo = olo
+	}
+	if ohi.Before(hi) {
+		hi = ohi
+	}
+
+	if !lo.Before(hi) {
+		return Interval{}, false
+	}
+
+	return Interval{Start: lo, Span: &Timespan{Duration: hi.Sub(lo)}}, true
+}
+
+// Shift returns a copy of i with its Start moved by the Timespan ts. The Span
+// is retained and re-evaluated from the new Start so, for example, shifting
+// a one month Interval from January into February makes it shorter.
+func (i Interval) Shift(ts *Timespan) Interval {
+	return Interval{Start: ts.From(i.Start), Span: i.Span}
+}
+
+// bounds returns the earlier and later ends of i.
+func (i Interval) bounds() (lo, hi time.Time) {
+	lo, hi = i.Start, i.End()
+	if hi.Before(lo) {
+		lo, hi = hi, lo
+	}
+	return lo, hi
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntervalContains(t *testing.T) {
+	start := time.Date(2019, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		span *Timespan
+		t    time.Time
+		want bool
+	}{
+		{"start", &Timespan{Days: 1}, start, true},
+		{"inside", &Timespan{Days: 1}, start.Add(23 * time.Hour), true},
+		{"end", &Timespan{Days: 1}, start.Add(24 * time.Hour), false},
+		{"before", &Timespan{Days: 1}, start.Add(-time.Nanosecond), false},
+		{"month-end", &Timespan{Months: 1}, time.Date(2019, 3, 3, 11, 0, 0, 0, time.UTC), true},
+		{"negative-start", &Timespan{Days: -1}, start, false},
+		{"negative-end", &Timespan{Days: -1}, start.Add(-24 * time.Hour), true},
+		{"negative-inside", &Timespan{Days: -1}, start.Add(-time.Hour), true},
+		{"zero", &Timespan{}, start, false},
+		{"nil", nil, start, false},
+		{"cancelling", &Timespan{Days: 1, Duration: -24 * time.Hour}, start, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			i := Interval{start, tc.span}
+			if got := i.Contains(tc.t); got != tc.want {
+				t.Errorf("Interval{%v, %v}.Contains(%v) == %v; Wanted %v", start, tc.span, tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIntervalDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	// Clocks in New York sprang forward on March 10, 2019 and fell back on
+	// November 3, 2019.
+	spring := Interval{time.Date(2019, 3, 10, 0, 0, 0, 0, ny), &Timespan{Days: 1}}
+	fall := Interval{time.Date(2019, 11, 3, 0, 0, 0, 0, ny), &Timespan{Days: 1}}
+
+	if got, want := spring.End().Sub(spring.Start), 23*time.Hour; got != want {
+		t.Errorf("spring-forward day lasts %v; Wanted %v", got, want)
+	}
+
+	if got, want := fall.End().Sub(fall.Start), 25*time.Hour; got != want {
+		t.Errorf("fall-back day lasts %v; Wanted %v", got, want)
+	}
+
+	late := time.Date(2019, 3, 11, 0, 30, 0, 0, ny)
+	if spring.Contains(late) {
+		t.Errorf("%v.Contains(%v) == true; Wanted false", spring, late)
+	}
+
+	// A 24 hour Interval crosses into the next calendar day.
+	if hours := (Interval{spring.Start, &Timespan{Duration: 24 * time.Hour}}); !hours.Contains(late) {
+		t.Errorf("%v.Contains(%v) == false; Wanted true", hours, late)
+	}
+
+	// The repeated 1 o'clock hour is part of the fall-back day.
+	if again := time.Date(2019, 11, 3, 5, 30, 0, 0, time.UTC); !fall.Contains(again) {
+		t.Errorf("%v.Contains(%v) == false; Wanted true", fall, again)
+	}
+
+	// Shifting the spring Interval by one day yields a full 24 hour day.
+	if next := spring.Shift(&Timespan{Days: 1}); next.End().Sub(next.Start) != 24*time.Hour {
+		t.Errorf("shifted Interval lasts %v; Wanted %v", next.End().Sub(next.Start), 24*time.Hour)
+	}
+}
+
+func TestIntervalIntersect(t *testing.T) {
+	base := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := &Timespan{Days: 1}
+
+	cases := []struct {
+		name   string
+		a, b   Interval
+		want   Interval
+		wantOK bool
+	}{
+		{
+			name:   "overlapping",
+			a:      Interval{base, day},
+			b:      Interval{base.Add(18 * time.Hour), day},
+			want:   Interval{base.Add(18 * time.Hour), &Timespan{Duration: 6 * time.Hour}},
+			wantOK: true,
+		},
+		{
+			name:   "nested",
+			a:      Interval{base, &Timespan{Months: 1}},
+			b:      Interval{base.Add(48 * time.Hour), day},
+			want:   Interval{base.Add(48 * time.Hour), &Timespan{Duration: 24 * time.Hour}},
+			wantOK: true,
+		},
+		{
+			name:   "negative",
+			a:      Interval{base, &Timespan{Days: -1}},
+			b:      Interval{base.Add(-2 * time.Hour), day},
+			want:   Interval{base.Add(-2 * time.Hour), &Timespan{Duration: 2 * time.Hour}},
+			wantOK: true,
+		},
+		{
+			name: "adjacent",
+			a:    Interval{base, day},
+			b:    Interval{base.Add(24 * time.Hour), day},
+		},
+		{
+			name: "disjoint",
+			a:    Interval{base, day},
+			b:    Interval{base.Add(72 * time.Hour), day},
+		},
+		{
+			name: "empty",
+			a:    Interval{base, day},
+			b:    Interval{base.Add(time.Hour), nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, pair := range [][2]Interval{{tc.a, tc.b}, {tc.b, tc.a}} {
+				got, ok := pair[0].Intersect(pair[1])
+				if ok != tc.wantOK {
+					t.Fatalf("%v.Intersect(%v) ok == %v; Wanted %v", pair[0], pair[1], ok, tc.wantOK)
+				}
+
+				if ok && (!got.Start.Equal(tc.want.Start) || !got.Span.Equal(tc.want.Span)) {
+					t.Errorf("%v.Intersect(%v) == %v; Wanted %v", pair[0], pair[1], got, tc.want)
+				}
+
+				if overlaps := pair[0].Overlaps(pair[1]); overlaps != tc.wantOK {
+					t.Errorf("%v.Overlaps(%v) == %v; Wanted %v", pair[0], pair[1], overlaps, tc.wantOK)
+				}
+			}
+		})
+	}
+}
+
+func TestIntervalIsEmpty(t *testing.T) {
+	now := time.Now()
+
+	for _, ts := range []*Timespan{nil, {}, {Days: 1, Duration: -24 * time.Hour}} {
+		if i := (Interval{now, ts}); !i.IsEmpty() {
+			t.Errorf("Interval{%v, %v}.IsEmpty() == false; Wanted true", now, ts)
+		}
+	}
+
+	for _, ts := range []*Timespan{{Duration: 1}, {Days: -1}} {
+		if i := (Interval{now, ts}); i.IsEmpty() {
+			t.Errorf("Interval{%v, %v}.IsEmpty() == true; Wanted false", now, ts)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"iter"
+	"time"
+)
+
+// Iter returns an iterator over the occurrences of ts beginning at start and
+// continuing up to, but not including, end. The occurrences are the same as
+// those produced by an Iterator from NewIterator, but are generated lazily
+// for use with a range statement:
+//
+//	for t := range ts.Iter(start, end) {
+//		...
+//	}
+//
+// Each step is applied to the previous occurrence so month-based spans may
+// drift near the end of a month. Iteration always terminates: if ts fails to
+// move forward in time at any occurrence (i.e. it is zero or negative), that
+// occurrence is the last one yielded.
+func (ts *Timespan) Iter(start, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		it := NewIterator(ts, start, end)
+		for t, ok := it.Next(); ok; t, ok = it.Next() {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleTimespan_Iter() {
+	ts := &Timespan{Days: 14}
+	start := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	for t := range ts.Iter(start, end) {
+		fmt.Println(t.Format("Jan 2"))
+	}
+	// Output:
+	// Mar 1
+	// Mar 15
+	// Mar 29
+	// Apr 12
+	// Apr 26
+}
+
+func TestTimespanIterMonths(t *testing.T) {
+	ts := &Timespan{Months: 1}
+
+	cases := []struct {
+		name    string
+		start   time.Time
+		wantDay int
+	}{
+		{"first", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 1},
+		{"month-end", time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC), 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			end := tc.start.AddDate(1, 0, 0)
+
+			var got []time.Time
+			for o := range ts.Iter(tc.start, end) {
+				got = append(got, o)
+			}
+
+			if len(got) != 12 {
+				t.Fatalf("(%v).Iter(%v, %v) yielded %d occurrences; Wanted 12", ts, tc.start, end, len(got))
+			}
+
+			// After Jan 31 + 1M drifts to Mar 3, every following occurrence
+			// stays on the 3rd.
+			for i, o := range got[1:] {
+				if o.Day() != tc.wantDay {
+					t.Errorf("occurrence #%d == %v; Wanted day %d", i+1, o, tc.wantDay)
+				}
+			}
+		})
+	}
+}
+
+func TestTimespanIterNonAdvancing(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	for _, ts := range []*Timespan{nil, {}, {Days: -1}} {
+		n := 0
+		for range ts.Iter(start, end) {
+			n++
+		}
+
+		if n != 1 {
+			t.Errorf("(%v).Iter yielded %d occurrences; Wanted 1", ts, n)
+		}
+	}
+}
+
+func TestTimespanIterBreak(t *testing.T) {
+	ts := &Timespan{Days: 1}
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	n := 0
+	for range ts.Iter(start, start.AddDate(1, 0, 0)) {
+		if n++; n == 3 {
+			break
+		}
+	}
+
+	if n != 3 {
+		t.Errorf("Iter continued after break: %d occurrences", n)
+	}
+}
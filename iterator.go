@@ -0,0 +1,174 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"time"
+)
+
+// An Iterator steps through the successive occurrences of a Timespan within
+// the half-open time range [start, end). The first occurrence is start itself
+// and each subsequent occurrence is the result of applying the Timespan to
+// the one before it.
+//
+// Note that, since each step is applied to the previous result, month-based
+// spans may drift near the end of a month (e.g. Jan 31 + "1M" is Mar 3 in a
+// non-leap year, and all following occurrences will fall on the 3rd).
+//
+// If applying the Timespan fails to move forward in time (i.e. it is zero or
+// negative at the current occurrence) the Iterator is exhausted after
+// returning that occurrence.
+type Iterator struct {
+	ts   *Timespan
+	next time.Time
+	end  time.Time
+	done bool
+}
+
+// NewIterator returns a new Iterator for the occurrences of ts beginning at
+// start and continuing up to, but not including, end.
+func NewIterator(ts *Timespan, start, end time.Time) *Iterator {
+	return &Iterator{
+		ts:   ts,
+		next: start,
+		end:  end,
+		done: !start.Before(end),
+	}
+}
+
+// Next returns the next occurrence and true, advancing the Iterator by one
+// step. Once the Iterator is exhausted, Next returns the zero time.Time and
+// false on this and every subsequent call.
+func (it *Iterator) Next() (time.Time, bool) {
+	t, ok := it.Peek()
+	if !ok {
+		return t, false
+	}
+
+	if n := it.ts.From(t); n.After(t) && n.Before(it.end) {
+		it.next = n
+	} else {
+		it.done = true
+	}
+
+	return t, true
+}
+
+// Peek returns the same values as Next but without advancing the Iterator.
+func (it *Iterator) Peek() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+
+	return it.next, true
+}
+
+// Times returns the first n occurrences of ts beginning at start: start
+// itself, then ts applied to start, then ts applied to that result, and so
+// on. An empty slice is returned if n is less than 1.
+//
+// Since each step is applied to the previous result, this may differ from
+// scaling the span (e.g. ts.MulInt(i).From(start)) for month-based spans.
+// Starting from Jan 31, stepwise application of "1M" yields Jan 31, Mar 3,
+// Apr 3 (in a non-leap year) while the scaled form yields Jan 31, Mar 3, Mar
+// 31.
+//
+// A negative span yields occurrences in reverse chronological order. If ts
+// resolves to zero at any step, no further occurrences are generated and the
+// returned slice is shorter than n; a zero span returns only start.
+func (ts *Timespan) Times(start time.Time, n int) []time.Time {
+	if n < 1 {
+		return []time.Time{}
+	}
+
+	out := make([]time.Time, 1, n)
+	out[0] = start
+
+	for t := start; len(out) < n; {
+		next := ts.From(t)
+		if next.Equal(t) {
+			break
+		}
+		out = append(out, next)
+		t = next
+	}
+
+	return out
+}
+
+// EqualSomewhere looks for a point in time at which ts and ots are equivalent
+// (as determined by EqualAt). The half-open range [start, end) is sampled at
+// each occurrence of step (as with an Iterator) and the first sample at which
+// the two are equivalent is returned along with true. If there is none, the
+// zero time.Time and false are returned.
+//
+// Note that only the sampled points are considered; a coincidence that only
+// occurs between samples (e.g. "1D" and "23h" on the one 23 hour day in a
+// range sampled every 2 days) is not found.
+func (ts *Timespan) EqualSomewhere(ots *Timespan, start, end time.Time, step *Timespan) (time.Time, bool) {
+	it := NewIterator(step, start, end)
+
+	for t, ok := it.Next(); ok; t, ok = it.Next() {
+		if ts.EqualAt(ots, t) {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Split divides the interval between start and ts.From(start) into n parts
+// of equal elapsed time and returns the n+1 boundaries between them, the
+// first of which is start and the last of which is ts.From(start). If the
+// interval's length (in nanoseconds) isn't evenly divisible by n, the
+// remainder is front-loaded: each of the first few parts is one nanosecond
+// longer than those that follow.
+//
+// The parts are equal in elapsed time, not in calendar terms; splitting "1Y"
+// into 12 parts yields parts of about 30.4 days each rather than months (use
+// Times for that) and a part spanning a DST transition has a different
+// wall-clock length than its neighbors. A negative span yields boundaries in
+// reverse chronological order and a span that resolves to zero yields n+1
+// copies of start.
+//
+// An error is returned if n is less than 1.
+func (ts *Timespan) Split(start time.Time, n int) ([]time.Time, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("timespan: cannot split into %d parts", n)
+	}
+
+	total := ts.AbsoluteAt(start)
+	q, r := total/time.Duration(n), total%time.Duration(n)
+
+	// r has the same sign as total
+	extra := time.Duration(1)
+	if r < 0 {
+		extra, r = -1, -r
+	}
+
+	out := make([]time.Time, n+1)
+	for i := range out {
+		x := time.Duration(i)
+		if x > r {
+			x = r
+		}
+		out[i] = start.Add(q*time.Duration(i) + extra*x)
+	}
+
+	return out, nil
+}
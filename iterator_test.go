@@ -0,0 +1,286 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIterator(t *testing.T) {
+	ts := &Timespan{Days: 10}
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	want := []time.Time{
+		time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 1, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 1, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	it := NewIterator(ts, start, end)
+
+	for i, w := range want {
+		if p, ok := it.Peek(); !ok || !p.Equal(w) {
+			t.Errorf("Peek() #%d == (%v, %v); Wanted (%v, true)", i, p, ok, w)
+		}
+
+		if got, ok := it.Next(); !ok || !got.Equal(w) {
+			t.Errorf("Next() #%d == (%v, %v); Wanted (%v, true)", i, got, ok, w)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		if got, ok := it.Next(); ok || !got.IsZero() {
+			t.Errorf("Next() on exhausted Iterator == (%v, %v); Wanted (%v, false)", got, ok, time.Time{})
+		}
+
+		if got, ok := it.Peek(); ok || !got.IsZero() {
+			t.Errorf("Peek() on exhausted Iterator == (%v, %v); Wanted (%v, false)", got, ok, time.Time{})
+		}
+	}
+}
+
+func TestIteratorMonthEnd(t *testing.T) {
+	ts := &Timespan{Months: 1}
+	start := time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	want := []time.Time{
+		time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 3, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 4, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	var got []time.Time
+	for it := NewIterator(ts, start, end); ; {
+		v, ok := it.Next()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Iterator produced %d occurrences; Wanted %d: %v", len(got), len(want), got)
+	}
+
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("Occurrence #%d: Got %v; Wanted %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIteratorNonAdvancing(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	for _, ts := range []*Timespan{{}, {Days: -1}} {
+		it := NewIterator(ts, start, end)
+
+		if got, ok := it.Next(); !ok || !got.Equal(start) {
+			t.Errorf("Iterator(%v).Next() == (%v, %v); Wanted (%v, true)", ts, got, ok, start)
+		}
+
+		if got, ok := it.Next(); ok {
+			t.Errorf("Iterator(%v) failed to terminate; Next() == (%v, %v)", ts, got, ok)
+		}
+	}
+}
+
+func TestIteratorEmptyRange(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if got, ok := NewIterator(&Timespan{Days: 1}, start, start).Next(); ok {
+		t.Errorf("Iterator over empty range returned (%v, %v); Wanted (%v, false)", got, ok, time.Time{})
+	}
+}
+
+func TestTimespanTimes(t *testing.T) {
+	cases := []struct {
+		name  string
+		ts    *Timespan
+		start time.Time
+		n     int
+		want  []time.Time
+	}{
+		{"weeks", &Timespan{Days: 14}, date(2019, 3, 1), 5, []time.Time{date(2019, 3, 1), date(2019, 3, 15), date(2019, 3, 29), date(2019, 4, 12), date(2019, 4, 26)}},
+		{"month-end", &Timespan{Months: 1}, date(2019, 1, 31), 4, []time.Time{date(2019, 1, 31), date(2019, 3, 3), date(2019, 4, 3), date(2019, 5, 3)}},
+		{"negative", &Timespan{Days: -1}, date(2019, 3, 2), 3, []time.Time{date(2019, 3, 2), date(2019, 3, 1), date(2019, 2, 28)}},
+		{"one", &Timespan{Days: 1}, date(2019, 3, 1), 1, []time.Time{date(2019, 3, 1)}},
+		{"zero-n", &Timespan{Days: 1}, date(2019, 3, 1), 0, []time.Time{}},
+		{"negative-n", &Timespan{Days: 1}, date(2019, 3, 1), -2, []time.Time{}},
+		{"zero-span", &Timespan{}, date(2019, 3, 1), 5, []time.Time{date(2019, 3, 1)}},
+		{"nil-span", nil, date(2019, 3, 1), 5, []time.Time{date(2019, 3, 1)}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.ts.Times(tc.start, tc.n)
+
+			if got == nil || len(got) != len(tc.want) {
+				t.Fatalf("(%v).Times(%v, %d) == %v; Wanted %v", tc.ts, tc.start, tc.n, got, tc.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					t.Errorf("(%v).Times(%v, %d)[%d] == %v; Wanted %v", tc.ts, tc.start, tc.n, i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTimespanTimesStepwiseVsScaled(t *testing.T) {
+	ts := &Timespan{Months: 1}
+	start := time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	got := ts.Times(start, 3)
+
+	// Stepwise: Jan 31 -> Mar 3 -> Apr 3
+	if want := time.Date(2019, 4, 3, 0, 0, 0, 0, time.UTC); !got[2].Equal(want) {
+		t.Errorf("stepwise occurrence #2 == %v; Wanted %v", got[2], want)
+	}
+
+	// Scaled: Jan 31 + 2M -> Mar 31
+	if scaled := ts.MulInt(2).From(start); scaled.Equal(got[2]) {
+		t.Errorf("scaled occurrence %v unexpectedly matches stepwise occurrence", scaled)
+	}
+}
+
+func TestTimespanEqualSomewhere(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	day := &Timespan{Days: 1}
+
+	cases := []struct {
+		name       string
+		ts, ots    *Timespan
+		start, end time.Time
+		step       *Timespan
+		want       time.Time
+		wantOK     bool
+	}{
+		{"2D-48h", &Timespan{Days: 2}, &Timespan{Duration: 48 * time.Hour}, time.Date(2019, 3, 9, 0, 0, 0, 0, ny), time.Date(2019, 3, 20, 0, 0, 0, 0, ny), day, time.Date(2019, 3, 11, 0, 0, 0, 0, ny), true},
+		{"2D-48h-short", &Timespan{Days: 2}, &Timespan{Duration: 48 * time.Hour}, time.Date(2019, 3, 9, 0, 0, 0, 0, ny), time.Date(2019, 3, 11, 0, 0, 0, 0, ny), day, time.Time{}, false},
+		{"1D-23h", day, &Timespan{Duration: 23 * time.Hour}, time.Date(2019, 3, 1, 0, 0, 0, 0, ny), time.Date(2019, 3, 31, 0, 0, 0, 0, ny), day, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), true},
+		{"1D-23h-sampled", day, &Timespan{Duration: 23 * time.Hour}, time.Date(2019, 3, 9, 0, 0, 0, 0, ny), time.Date(2019, 3, 31, 0, 0, 0, 0, ny), &Timespan{Days: 2}, time.Time{}, false},
+		{"1M-30D", &Timespan{Months: 1}, &Timespan{Days: 30}, time.Date(2019, 1, 1, 0, 0, 0, 0, ny), time.Date(2019, 12, 31, 0, 0, 0, 0, ny), &Timespan{Months: 1}, time.Date(2019, 4, 1, 0, 0, 0, 0, ny), true},
+		{"never", day, &Timespan{Days: 2}, time.Date(2019, 1, 1, 0, 0, 0, 0, ny), time.Date(2019, 12, 31, 0, 0, 0, 0, ny), day, time.Time{}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.ts.EqualSomewhere(tc.ots, tc.start, tc.end, tc.step)
+			if ok != tc.wantOK || !got.Equal(tc.want) {
+				t.Errorf("(%v).EqualSomewhere(%v, %v, %v, %v) == (%v, %v); Wanted (%v, %v)", tc.ts, tc.ots, tc.start, tc.end, tc.step, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestTimespanSplit(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		ts   *Timespan
+		n    int
+		want []time.Duration // offsets from start
+	}{
+		{"even", &Timespan{Days: 1}, 4, []time.Duration{0, 6 * time.Hour, 12 * time.Hour, 18 * time.Hour, 24 * time.Hour}},
+		{"remainder", &Timespan{Duration: 10}, 3, []time.Duration{0, 4, 7, 10}},
+		{"negative-remainder", &Timespan{Duration: -10}, 3, []time.Duration{0, -4, -7, -10}},
+		{"one", &Timespan{Months: 1}, 1, []time.Duration{0, 31 * 24 * time.Hour}},
+		{"zero", &Timespan{}, 2, []time.Duration{0, 0, 0}},
+		{"nil", nil, 1, []time.Duration{0, 0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.ts.Split(start, tc.n)
+			if err != nil {
+				t.Fatalf("(%v).Split(%v, %d) returned error: %v", tc.ts, start, tc.n, err)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("(%v).Split(%v, %d) returned %d boundaries; Wanted %d", tc.ts, start, tc.n, len(got), len(tc.want))
+			}
+
+			for i, w := range tc.want {
+				if got[i].Sub(start) != w {
+					t.Errorf("(%v).Split(%v, %d)[%d] == %v; Wanted %v", tc.ts, start, tc.n, i, got[i], start.Add(w))
+				}
+			}
+		})
+	}
+}
+
+func TestTimespanSplitYear(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := &Timespan{Years: 1}
+
+	got, err := ts.Split(start, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got[12].Equal(ts.From(start)) {
+		t.Errorf("last boundary == %v; Wanted %v", got[12], ts.From(start))
+	}
+
+	// The parts are equal in elapsed time, not calendar months, and any
+	// remainder is front-loaded.
+	for i := 1; i < 12; i++ {
+		if d := got[i+1].Sub(got[i]) - got[i].Sub(got[i-1]); d < -1 || d > 0 {
+			t.Errorf("part %d differs from part %d by %v", i+1, i, d)
+		}
+	}
+}
+
+func TestTimespanSplitDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	start := time.Date(2019, 3, 10, 0, 0, 0, 0, ny)
+
+	got, err := (&Timespan{Days: 1}).Split(start, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The 23 hour day splits into two 11h30m parts; by the wall clock, the
+	// first runs from midnight to 12:30 and the second until midnight.
+	if want := time.Date(2019, 3, 10, 12, 30, 0, 0, ny); !got[1].Equal(want) {
+		t.Errorf("midpoint == %v; Wanted %v", got[1], want)
+	}
+
+	for i := 1; i < len(got); i++ {
+		if d := got[i].Sub(got[i-1]); d != 11*time.Hour+30*time.Minute {
+			t.Errorf("part %d lasts %v; Wanted %v", i, d, 11*time.Hour+30*time.Minute)
+		}
+	}
+}
+
+func TestTimespanSplitBadN(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if got, err := (&Timespan{Days: 1}).Split(time.Now(), n); err == nil {
+			t.Errorf("Split(%d) == %v; Wanted an error", n, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"sort"
+	"strings"
+)
+
+// keywords maps each keyword accepted by ParseKeyword to its Timespan.
+var keywords = map[string]Timespan{
+	"daily":       {Days: 1},
+	"weekly":      {Weeks: 1},
+	"fortnightly": {Weeks: 2},
+	"monthly":     {Months: 1},
+	"quarterly":   {Months: 3},
+	"yearly":      {Years: 1},
+}
+
+// ParseKeyword returns a pointer to a new Timespan for one of the following
+// keywords, matched without regard to case or surrounding whitespace:
+//
+//	daily        1D
+//	weekly       1W
+//	fortnightly  2W
+//	monthly      1M
+//	quarterly    3M
+//	yearly       1Y
+//
+// This allows a configuration to offer these familiar words in place of the
+// syntax accepted by ParseTimespan. Any other input results in a *ParseError
+// of type UnknownKeywordErr whose message lists the accepted keywords.
+func ParseKeyword(s string) (*Timespan, error) {
+	ts, ok := keywords[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return nil, timespanError(UnknownKeywordErr, "unknown keyword; must be one of: %s", strings.Join(keywordList(), ", ")).withTimespan(s)
+	}
+
+	return &ts, nil
+}
+
+// keywordList returns the keywords accepted by ParseKeyword in order of
+// increasing length.
+func keywordList() []string {
+	list := make([]string, 0, len(keywords))
+	for k := range keywords {
+		list = append(list, k)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := keywords[list[i]], keywords[list[j]]
+		return a.Compare(&b) < 0
+	})
+
+	return list
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseKeyword(t *testing.T) {
+	cases := []struct {
+		str  string
+		want *Timespan
+	}{
+		{"daily", &Timespan{Days: 1}},
+		{"weekly", &Timespan{Weeks: 1}},
+		{"fortnightly", &Timespan{Weeks: 2}},
+		{"monthly", &Timespan{Months: 1}},
+		{"quarterly", &Timespan{Months: 3}},
+		{"yearly", &Timespan{Years: 1}},
+		{"Monthly", &Timespan{Months: 1}},
+		{" YEARLY\n", &Timespan{Years: 1}},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseKeyword(tc.str)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("ParseKeyword(%q) == (%v, %v); Wanted (%v, nil)", tc.str, got, err, tc.want)
+		}
+	}
+
+	// The result must not alias the keyword table
+	got, _ := ParseKeyword("daily")
+	got.Days = 5
+	if again, _ := ParseKeyword("daily"); again.Days != 1 {
+		t.Errorf("ParseKeyword(%q) == %v after modifying an earlier result; Wanted 1D", "daily", again)
+	}
+}
+
+func TestParseKeywordUnknown(t *testing.T) {
+	for _, s := range []string{"", "hourly", "1D", "bi-weekly"} {
+		got, err := ParseKeyword(s)
+
+		pe, ok := err.(*ParseError)
+		if !ok || got != nil {
+			t.Errorf("ParseKeyword(%q) == (%v, %v); Wanted a %v ParseError", s, got, err, UnknownKeywordErr)
+			continue
+		}
+
+		if pe.Type() != UnknownKeywordErr || pe.Input() != s {
+			t.Errorf("ParseKeyword(%q) error == (%v, %q); Wanted (%v, %q)", s, pe.Type(), pe.Input(), UnknownKeywordErr, s)
+		}
+
+		if want := "daily, weekly, fortnightly, monthly, quarterly, yearly"; !strings.HasSuffix(pe.Error(), want) {
+			t.Errorf("ParseKeyword(%q) error == %q; Wanted it to list %q", s, pe.Error(), want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A ListError is returned by ParseList when one of its elements can't be
+// parsed. Err is the underlying error, which is usually a *ParseError.
+type ListError struct {
+	Index int // zero-based position of the failing element
+	Err   error
+}
+
+// Error is part of the error interface
+func (le *ListError) Error() string {
+	return fmt.Sprintf("timespan list element %d: %v", le.Index, le.Err)
+}
+
+// ParseList splits s on sep and parses each element as with
+// ParseTimespanWith, applying opts to every one; e.g. "1D,1W,1M" with a sep
+// of "," yields three Timespans. Surrounding whitespace is trimmed from each
+// element so "1D, 1W" is also accepted.
+//
+// By default, an empty element is an error; the SkipEmpty option causes
+// empty elements to be ignored instead. The first element that fails to
+// parse is reported as a *ListError identifying its index.
+func ParseList(s, sep string, opts ...ParseOption) ([]*Timespan, error) {
+	po := newParseOptions(opts)
+
+	var list []*Timespan
+	for i, elem := range strings.Split(s, sep) {
+		elem = strings.TrimSpace(elem)
+		if elem == "" && po.skipEmpty {
+			continue
+		}
+
+		ts := &Timespan{}
+		if err := parseTimespanInto(elem, ts, po); err != nil {
+			return nil, &ListError{Index: i, Err: err}
+		}
+
+		list = append(list, ts)
+	}
+
+	return list, nil
+}
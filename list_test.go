@@ -0,0 +1,83 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	cases := []struct {
+		name  string
+		s     string
+		sep   string
+		opts  []ParseOption
+		want  []*Timespan
+		index int // of the failing element, or -1
+		etype ErrType
+	}{
+		{"tiers", "1D,1W,1M", ",", nil, []*Timespan{{Days: 1}, {Weeks: 1}, {Months: 1}}, -1, NoErr},
+		{"spaces", "1D, 1W ,\t36h", ",", nil, []*Timespan{{Days: 1}, {Weeks: 1}, {Duration: 36 * time.Hour}}, -1, NoErr},
+		{"separator", "1Y|-2M", "|", nil, []*Timespan{{Years: 1}, {Months: -2}}, -1, NoErr},
+		{"single", "1Y", ",", nil, []*Timespan{{Years: 1}}, -1, NoErr},
+		{"bad", "1D,1D2W,1M", ",", nil, nil, 1, MagnOutOfOrderErr},
+		{"empty-elem", "1D,,1M", ",", nil, nil, 1, EmptyInputErr},
+		{"empty-last", "1D,1M, ", ",", nil, nil, 2, EmptyInputErr},
+		{"skip-empty", "1D,,1M, ", ",", []ParseOption{SkipEmpty()}, []*Timespan{{Days: 1}, {Months: 1}}, -1, NoErr},
+		{"skip-all", "", ",", []ParseOption{SkipEmpty()}, nil, -1, NoErr},
+		{"bounds", "1D,11Y", ",", []ParseOption{Bounds(&Timespan{Years: 10})}, nil, 1, OutOfBoundsErr},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseList(tc.s, tc.sep, tc.opts...)
+
+			if tc.index < 0 {
+				if err != nil {
+					t.Fatalf("ParseList(%q, %q) failed: %v", tc.s, tc.sep, err)
+				}
+			} else {
+				le, ok := err.(*ListError)
+				if !ok {
+					t.Fatalf("ParseList(%q, %q) error == %#v; Wanted *ListError", tc.s, tc.sep, err)
+				}
+				if le.Index != tc.index || errType(le.Err) != tc.etype {
+					t.Errorf("ParseList(%q, %q) error == (%d, %v); Wanted (%d, %v)", tc.s, tc.sep, le.Index, errType(le.Err), tc.index, tc.etype)
+				}
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("ParseList(%q, %q) == %v; Wanted %v", tc.s, tc.sep, got, tc.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					t.Errorf("ParseList(%q, %q)[%d] == %v; Wanted %v", tc.s, tc.sep, i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestListErrorMessage(t *testing.T) {
+	_, err := ParseList("1D,bogus", ",")
+	if want := `timespan list element 1: parsing Timespan "bogus": `; err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("ParseList error == %v; Wanted prefix %q", err, want)
+	}
+}
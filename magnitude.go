@@ -32,44 +32,44 @@ func (m *magnitude) set(val int) {
 	m.isSet = true
 }
 
-type magset map[rune]*magnitude
+// A magset holds one magnitude for each glyph in magOrder, at the same
+// index. It is a fixed-size array (rather than a map) so that parsing a
+// Timespan needn't allocate.
+type magset [len(magOrder)]magnitude
+
+var magLabels = [len(magOrder)]string{"year", "month", "week", "day"}
 
 func newMagset() magset {
-	return magset(map[rune]*magnitude{
-		'Y': {label: "year"},
-		'M': {label: "month"},
-		'W': {label: "week"},
-		'D': {label: "day"},
-	})
+	var ms magset
+	for i := range ms {
+		ms[i].label = magLabels[i]
+	}
+	return ms
 }
 
-func (ms magset) get(r rune) int {
-	return ms[r].value
+func (ms *magset) get(r rune) int {
+	return ms[strings.IndexRune(magOrder, r)].value
 }
 
-func (ms magset) set(r rune, val int) *timespanErr {
+func (ms *magset) set(r rune, val int) *ParseError {
 	if r == 'd' {
 		r = 'D'
 	}
 
-	m, ok := ms[r]
-	if !ok {
-		return timespanError(unrecognizedMagErr, "unrecognized magnitude: %q", string(r))
-	}
-
-	i := strings.Index(magOrder, string(r))
+	i := strings.IndexRune(magOrder, r)
 	if i < 0 {
-		return timespanError(magnOrderUnkownErr, "indeterminate order for magnitude: %q", string(r))
+		return timespanError(UnrecognizedMagErr, "unrecognized magnitude: %q", string(r))
 	}
 
+	m := &ms[i]
+
 	if m.isSet {
-		return timespanError(magnRestatedErr, "magnitude %c restated (current:%d%c previous:%d%c)", r, val, r, m.value, r)
+		return timespanError(MagnRestatedErr, "magnitude %c restated (current:%d%c previous:%d%c)", r, val, r, m.value, r)
 	}
 
-	for _, g := range magOrder[i:] {
-		om := ms[g]
-		if om.isSet {
-			return timespanError(magnOutOfOrderError, "magnitude out of order: %s specified before %s", om.label, m.label)
+	for j := i; j < len(ms); j++ {
+		if om := &ms[j]; om.isSet {
+			return timespanError(MagnOutOfOrderErr, "magnitude out of order: %s specified before %s", om.label, m.label)
 		}
 	}
 
@@ -36,9 +36,9 @@ func ve(g rune, l string, v int) *element {
 }
 
 func mkMagset(mags ...*element) magset {
-	ms := magset(map[rune]*magnitude{})
+	var ms magset
 	for _, m := range mags {
-		ms[m.glyph] = m.magn
+		ms[strings.IndexRune(magOrder, m.glyph)] = *m.magn
 	}
 	return ms
 }
@@ -47,8 +47,8 @@ func mkMagset(mags ...*element) magset {
 // to present magsets in a human readable form in testing errors
 func (ms magset) String() string {
 	ml := []string{}
-	for g, m := range ms {
-		ml = append(ml, fmt.Sprintf("'%c': {label: %q, isSet: %v, value: %d}", g, m.label, m.isSet, m.value))
+	for i, m := range ms {
+		ml = append(ml, fmt.Sprintf("'%c': {label: %q, isSet: %v, value: %d}", magOrder[i], m.label, m.isSet, m.value))
 	}
 	return fmt.Sprintf("magset{%s}", strings.Join(ml, ", "))
 }
@@ -56,30 +56,19 @@ func (ms magset) String() string {
 // A utility functions for comparing two magsets
 // (here since it's used only for testing)
 func (ms magset) equal(oms magset) bool {
-	for g, m := range ms {
-		om, ok := oms[g]
-		if !ok {
-			return false
-		}
-
-		if !(m.label == om.label && m.isSet == om.isSet && m.value == om.value) {
-			return false
-		}
-	}
-
-	return true
+	return ms == oms
 }
 
 // A quick test to ensure the 'equal()' function above actually works properly
 func TestMagsetEqual(t *testing.T) {
-	m1 := mkMagset(be('A', "one"), ve('B', "two", 42))
-	m2 := mkMagset(be('A', "one"), ve('B', "two", 42))
+	m1 := mkMagset(be('Y', "year"), ve('M', "month", 42))
+	m2 := mkMagset(be('Y', "year"), ve('M', "month", 42))
 
 	if !m1.equal(m2) {
 		t.Errorf("magset.equal() failed to recognize identical magsets:\n\t1: %v\n\t2: %v", m1, m2)
 	}
 
-	m2['A'].set(1)
+	m2[0].set(1)
 
 	if m1.equal(m2) {
 		t.Errorf("magset.equal() failed to recognize dissimilar magsets:\n\t1: %v\n\t2: %v", m1, m2)
@@ -113,21 +102,10 @@ func TestMagsetGood(t *testing.T) {
 }
 
 func TestMagsetBadRune(t *testing.T) {
-	ms := mkMagset(be('A', "one"), ve('B', "two", 42))
-
-	if err := ms.set('C', 1); err.errorType != unrecognizedMagErr {
-		t.Errorf("attempting to set an unknown magnitude: bad error type: Got:%v Wanted:%v", err.errorType, unrecognizedMagErr)
-	}
-}
-
-func TestMagsetUnkownOrder(t *testing.T) {
-	ms := mkMagset(be('A', "one"), ve('B', "two", 42))
+	ms := newMagset()
 
-	err := ms.set('A', 1)
-	if err == nil {
-		t.Errorf("attempting to set magnitude of unknown order: no error returned when expected: wanted %v", magnOrderUnkownErr)
-	} else if err.errorType != magnOrderUnkownErr {
-		t.Errorf("attempting to set magnitude of unknown order: bad error type: Got:%v  Wanted:%v", err.errorType, magnOrderUnkownErr)
+	if err := ms.set('C', 1); err == nil || err.errorType != UnrecognizedMagErr {
+		t.Errorf("attempting to set an unknown magnitude: bad error type: Got:%v Wanted:%v", err, UnrecognizedMagErr)
 	}
 }
 
@@ -137,31 +115,31 @@ func TestMagset(t *testing.T) {
 			ms := newMagset()
 
 			if err := ms.set(r1, i1); err != nil {
-				t.Errorf("setting initial magnitude value for %q: unexpected error: Got:%v Wanted:%v", ms[r1].label, err, nil)
+				t.Errorf("setting initial magnitude value for %q: unexpected error: Got:%v Wanted:%v", ms[i1].label, err, nil)
 				continue
 			} else {
 				if v := ms.get(r1); v != i1 {
-					t.Errorf("bad value from magset.get(%q): Got:%d Wanted:%d", ms[r1].label, v, i1)
+					t.Errorf("bad value from magset.get(%q): Got:%d Wanted:%d", ms[i1].label, v, i1)
 				}
 			}
 
 			switch {
 			case i1 == i2:
-				if err := ms.set(r2, 2); err.errorType != magnRestatedErr {
-					t.Errorf("restating magnitude value for %q: bad error type: Got:%v Wanted:%v", ms[r2].label, err, magnRestatedErr)
+				if err := ms.set(r2, 2); err.errorType != MagnRestatedErr {
+					t.Errorf("restating magnitude value for %q: bad error type: Got:%v Wanted:%v", ms[i2].label, err, MagnRestatedErr)
 				}
 
 			case i1 < i2:
 				if err := ms.set(r2, i2); err != nil {
-					t.Errorf("setting magnitude value for %q after %q: unexpected error: Got:%v Wanted:%v", ms[r2].label, ms[r1].label, err, nil)
+					t.Errorf("setting magnitude value for %q after %q: unexpected error: Got:%v Wanted:%v", ms[i2].label, ms[i1].label, err, nil)
 				}
 
 			case i1 > i2:
 				err := ms.set(r2, i2)
 				if err == nil {
-					t.Errorf("setting magnitude value for %q after %q: no error returned when expected: wanted %v", ms[r2].label, ms[r1].label, magnOutOfOrderError)
-				} else if err.errorType != magnOutOfOrderError {
-					t.Errorf("setting magnitude value for %q after %q: bad error type: Got:%v  Wanted:%v", ms[r2].label, ms[r1].label, err, magnOutOfOrderError)
+					t.Errorf("setting magnitude value for %q after %q: no error returned when expected: wanted %v", ms[i2].label, ms[i1].label, MagnOutOfOrderErr)
+				} else if err.errorType != MagnOutOfOrderErr {
+					t.Errorf("setting magnitude value for %q after %q: bad error type: Got:%v  Wanted:%v", ms[i2].label, ms[i1].label, err, MagnOutOfOrderErr)
 				}
 			}
 		}
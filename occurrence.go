@@ -0,0 +1,176 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"time"
+)
+
+// NextAfter returns the first occurrence of ts, in the series of occurrences
+// beginning at anchor, that is strictly after t. If t falls exactly on an
+// occurrence, the following one is returned. If t is before anchor, anchor
+// itself is returned.
+//
+// The k-th occurrence is ts.MulInt(k).From(anchor) (for k = 0, 1, 2, ...);
+// each is calculated from the anchor rather than from the one before it,
+// so (unlike an Iterator) month-end anchors do not drift: the occurrences
+// of "1M" anchored on Jan 31, 2019 are Jan 31, Mar 3, Mar 31, May 1, May 31
+// and so on.
+//
+// The occurrence is located without stepping through the series so t may be
+// arbitrarily far from anchor. If ts does not resolve to a positive span at
+// anchor, there is no series and the zero time.Time is returned.
+func (ts *Timespan) NextAfter(anchor, t time.Time) time.Time {
+	k, ok := ts.occurrenceIndex(anchor, t)
+	if !ok {
+		return time.Time{}
+	}
+
+	return ts.MulInt(k + 1).From(anchor)
+}
+
+// PrevAtOrBefore returns the latest occurrence of ts, in the series of
+// occurrences beginning at anchor, that is not after t. If t falls exactly on
+// an occurrence, t is returned. See NextAfter for how occurrences are
+// calculated.
+//
+// If t is before anchor, or if ts does not resolve to a positive span at
+// anchor, the zero time.Time is returned.
+func (ts *Timespan) PrevAtOrBefore(anchor, t time.Time) time.Time {
+	k, ok := ts.occurrenceIndex(anchor, t)
+	if !ok || k < 0 {
+		return time.Time{}
+	}
+
+	return ts.MulInt(k).From(anchor)
+}
+
+// CountBetween returns the number of occurrences of ts, in the series of
+// occurrences beginning at anchor, that fall within the half-open range
+// [from, to). Occurrences are calculated just as for NextAfter, using the
+// actual length of each month or year, and are counted without stepping
+// through the series.
+//
+// Zero is returned if from is not before to or if ts does not resolve to a
+// positive span at anchor. Occurrences are never before anchor.
+func (ts *Timespan) CountBetween(anchor, from, to time.Time) int {
+	if !from.Before(to) {
+		return 0
+	}
+
+	n, ok := ts.countBefore(anchor, to)
+	if !ok {
+		return 0
+	}
+
+	m, _ := ts.countBefore(anchor, from)
+
+	return n - m
+}
+
+// countBefore returns the number of occurrences of ts from anchor that are
+// strictly before t.
+func (ts *Timespan) countBefore(anchor, t time.Time) (int, bool) {
+	k, ok := ts.occurrenceIndex(anchor, t)
+	if !ok || k < 0 {
+		return 0, ok
+	}
+
+	if ts.MulInt(k).From(anchor).Equal(t) {
+		return k, true
+	}
+
+	return k + 1, true
+}
+
+// occurrenceIndex returns the largest k such that ts.MulInt(k).From(anchor)
+// is not after t, or -1 if t is before anchor. The returned bool is false if
+// ts does not resolve to a positive span at anchor.
+func (ts *Timespan) occurrenceIndex(anchor, t time.Time) (int, bool) {
+	step := ts.AbsoluteAt(anchor)
+	if step <= 0 {
+		return 0, false
+	}
+
+	if t.Before(anchor) {
+		return -1, true
+	}
+
+	return ts.floorIndex(anchor, t, step), true
+}
+
+// floorIndex returns the largest k, which may be negative, such that
+// ts.MulInt(k).From(anchor) is not after t. The positive step is ts's span at
+// anchor, which is used to estimate k.
+func (ts *Timespan) floorIndex(anchor, t time.Time, step time.Duration) int {
+	at := func(k int) time.Time { return ts.MulInt(k).From(anchor) }
+
+	// The length of step is only an estimate of each occurrence's length
+	// (months and years vary) so refine the estimate a few times before
+	// correcting it one occurrence at a time.
+	k := 0
+	for i := 0; i < 8; i++ {
+		adj := int(t.Sub(at(k)) / step)
+		if adj == 0 {
+			break
+		}
+		k += adj
+	}
+
+	for at(k).After(t) {
+		k--
+	}
+
+	for !at(k + 1).After(t) {
+		k++
+	}
+
+	return k
+}
+
+// DivModAt divides ts by period as evaluated at Time at. Occurrences of
+// period are laid out from at just as for NextAfter, using the actual length
+// of each month or year, and n is the number of whole occurrences that fit
+// within ts (i.e. the largest n for which period.MulInt(n).From(at) is not
+// after ts.From(at)). The remainder is the time from that occurrence to
+// ts.From(at), and so is never negative; e.g. with a period of "1M", "45D"
+// from January 1, 2019 is 1 month with a remainder of 14 days while from
+// February 1 it is 1 month with a remainder of 17 days.
+//
+// If ts resolves to a point before at, n is negative; "-10D" from March 1,
+// 2019 is -1 month with a remainder of 18 days. An error is returned if
+// period does not resolve to a positive span at at. A nil ts is treated as
+// a zero span.
+func (ts *Timespan) DivModAt(period *Timespan, at time.Time) (n int, rem time.Duration, err error) {
+	step := period.AbsoluteAt(at)
+	if step <= 0 {
+		return 0, 0, fmt.Errorf("timespan: period %v is not positive at %v", period, at)
+	}
+
+	end := ts.From(at)
+	n = period.floorIndex(at, end, step)
+
+	return n, end.Sub(period.MulInt(n).From(at)), nil
+}
+
+// ModAt is like DivModAt but returns only the remainder; i.e. how far ts
+// reaches into the current occurrence of period.
+func (ts *Timespan) ModAt(period *Timespan, at time.Time) (time.Duration, error) {
+	_, rem, err := ts.DivModAt(period, at)
+	return rem, err
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestTimespanNextPrevMonthEnd(t *testing.T) {
+	ts := &Timespan{Months: 1}
+	anchor := date(2019, 1, 31)
+
+	cases := []struct {
+		t    time.Time
+		next time.Time
+		prev time.Time
+	}{
+		{date(2019, 1, 31), date(2019, 3, 3), date(2019, 1, 31)},
+		{date(2019, 2, 15), date(2019, 3, 3), date(2019, 1, 31)},
+		{date(2019, 3, 3), date(2019, 3, 31), date(2019, 3, 3)},
+		{date(2019, 4, 15), date(2019, 5, 1), date(2019, 3, 31)},
+		{date(2019, 12, 31), date(2020, 1, 31), date(2019, 12, 31)},
+		{date(2020, 2, 15), date(2020, 3, 2), date(2020, 1, 31)},
+		{date(2041, 4, 15), date(2041, 5, 1), date(2041, 3, 31)},
+		{date(2019, 1, 1), date(2019, 1, 31), time.Time{}},
+	}
+
+	for _, tc := range cases {
+		if got := ts.NextAfter(anchor, tc.t); !got.Equal(tc.next) {
+			t.Errorf("(%v).NextAfter(%v, %v) == %v; Wanted %v", ts, anchor, tc.t, got, tc.next)
+		}
+
+		if got := ts.PrevAtOrBefore(anchor, tc.t); !got.Equal(tc.prev) {
+			t.Errorf("(%v).PrevAtOrBefore(%v, %v) == %v; Wanted %v", ts, anchor, tc.t, got, tc.prev)
+		}
+	}
+}
+
+func TestTimespanNextPrevDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	ts := &Timespan{Days: 1}
+	anchor := time.Date(2019, 3, 1, 9, 0, 0, 0, ny)
+	now := time.Date(2019, 3, 15, 8, 0, 0, 0, ny)
+
+	// Daily occurrences keep to 9am local time across the transition.
+	if got, want := ts.NextAfter(anchor, now), time.Date(2019, 3, 15, 9, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("(%v).NextAfter(%v, %v) == %v; Wanted %v", ts, anchor, now, got, want)
+	}
+
+	if got, want := ts.PrevAtOrBefore(anchor, now), time.Date(2019, 3, 14, 9, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("(%v).PrevAtOrBefore(%v, %v) == %v; Wanted %v", ts, anchor, now, got, want)
+	}
+
+	// ...whereas 24 hour occurrences do not.
+	hours := &Timespan{Duration: 24 * time.Hour}
+	if got, want := hours.NextAfter(anchor, now), time.Date(2019, 3, 15, 10, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("(%v).NextAfter(%v, %v) == %v; Wanted %v", hours, anchor, now, got, want)
+	}
+}
+
+func TestTimespanNextPrevMatchesIteration(t *testing.T) {
+	anchor := time.Date(1999, 1, 31, 12, 0, 0, 0, time.UTC)
+	rng := rand.New(rand.NewSource(1))
+
+	for _, ts := range []*Timespan{{Months: 1}, {Years: 1, Months: 2, Days: 3}, {Days: 7}, {Duration: 36 * time.Hour}} {
+		var series []time.Time
+		for k := 0; len(series) == 0 || series[len(series)-1].Year() < 2050; k++ {
+			series = append(series, ts.MulInt(k).From(anchor))
+		}
+
+		for i := 0; i < 200; i++ {
+			j := rng.Intn(len(series) - 1)
+			now := series[j].Add(time.Duration(rng.Int63n(int64(series[j+1].Sub(series[j])))))
+
+			if got := ts.NextAfter(anchor, now); !got.Equal(series[j+1]) {
+				t.Fatalf("(%v).NextAfter(%v, %v) == %v; Wanted %v", ts, anchor, now, got, series[j+1])
+			}
+
+			if got := ts.PrevAtOrBefore(anchor, now); !got.Equal(series[j]) {
+				t.Fatalf("(%v).PrevAtOrBefore(%v, %v) == %v; Wanted %v", ts, anchor, now, got, series[j])
+			}
+		}
+	}
+}
+
+func TestTimespanNextPrevNonPositive(t *testing.T) {
+	anchor := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := anchor.AddDate(1, 0, 0)
+
+	for _, ts := range []*Timespan{nil, {}, {Days: -1}} {
+		if got := ts.NextAfter(anchor, now); !got.IsZero() {
+			t.Errorf("(%v).NextAfter(%v, %v) == %v; Wanted zero time", ts, anchor, now, got)
+		}
+
+		if got := ts.PrevAtOrBefore(anchor, now); !got.IsZero() {
+			t.Errorf("(%v).PrevAtOrBefore(%v, %v) == %v; Wanted zero time", ts, anchor, now, got)
+		}
+	}
+}
+
+func TestTimespanCountBetween(t *testing.T) {
+	fortnight := &Timespan{Days: 14}
+	month := &Timespan{Months: 1}
+	signup := date(2019, 1, 1)
+
+	cases := []struct {
+		name     string
+		ts       *Timespan
+		anchor   time.Time
+		from, to time.Time
+		want     int
+	}{
+		{"fortnights", fortnight, signup, signup, date(2019, 3, 1), 5},
+		{"fortnights-on-boundary", fortnight, signup, signup, date(2019, 1, 29), 2},
+		{"fortnights-past-boundary", fortnight, signup, signup, date(2019, 1, 29).Add(1), 3},
+		{"from-on-boundary", fortnight, signup, date(2019, 1, 15), date(2019, 1, 30), 2},
+		{"empty", fortnight, signup, date(2019, 1, 15), date(2019, 1, 15), 0},
+		{"inverted", fortnight, signup, date(2019, 3, 1), date(2019, 1, 1), 0},
+		{"before-anchor", fortnight, signup, date(2018, 1, 1), date(2018, 12, 31), 0},
+		{"straddles-anchor", fortnight, signup, date(2018, 1, 1), date(2019, 1, 2), 1},
+		{"february", month, date(2019, 1, 31), date(2019, 2, 1), date(2019, 3, 1), 0},
+		{"march", month, date(2019, 1, 31), date(2019, 3, 1), date(2019, 4, 1), 2},
+		{"year", month, date(2019, 1, 31), date(2019, 1, 1), date(2020, 1, 1), 12},
+		{"decades", month, date(2019, 1, 31), date(2019, 1, 1), date(2069, 1, 1), 600},
+		{"zero-span", &Timespan{}, signup, signup, date(2020, 1, 1), 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ts.CountBetween(tc.anchor, tc.from, tc.to); got != tc.want {
+				t.Errorf("(%v).CountBetween(%v, %v, %v) == %d; Wanted %d", tc.ts, tc.anchor, tc.from, tc.to, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimespanDivModAt(t *testing.T) {
+	const day = 24 * time.Hour
+	month := &Timespan{Months: 1}
+
+	cases := []struct {
+		ts     *Timespan
+		period *Timespan
+		at     time.Time
+		n      int
+		rem    time.Duration
+	}{
+		{&Timespan{Days: 45}, month, date(2019, 1, 1), 1, 14 * day},
+		{&Timespan{Days: 45}, month, date(2019, 2, 1), 1, 17 * day},
+		{&Timespan{Days: 45}, month, date(2020, 2, 1), 1, 16 * day},
+		{&Timespan{Months: 3}, month, date(2019, 1, 31), 3, 0},
+		{&Timespan{Days: 58}, month, date(2019, 1, 31), 1, 27 * day},
+		{&Timespan{Years: 2, Duration: time.Hour}, &Timespan{Weeks: 1}, date(2019, 1, 1), 104, 3*day + time.Hour},
+		{&Timespan{Days: -10}, month, date(2019, 3, 1), -1, 18 * day},
+		{&Timespan{Months: -2}, month, date(2019, 3, 1), -2, 0},
+		{nil, month, date(2019, 3, 1), 0, 0},
+		{&Timespan{Duration: 90 * time.Minute}, &Timespan{Duration: time.Hour}, date(2019, 3, 1), 1, 30 * time.Minute},
+	}
+
+	for _, tc := range cases {
+		n, rem, err := tc.ts.DivModAt(tc.period, tc.at)
+		if err != nil || n != tc.n || rem != tc.rem {
+			t.Errorf("(%v).DivModAt(%v, %v) == (%d, %v, %v); Wanted (%d, %v, nil)", tc.ts, tc.period, tc.at, n, rem, err, tc.n, tc.rem)
+		}
+
+		if rem, err := tc.ts.ModAt(tc.period, tc.at); err != nil || rem != tc.rem {
+			t.Errorf("(%v).ModAt(%v, %v) == (%v, %v); Wanted (%v, nil)", tc.ts, tc.period, tc.at, rem, err, tc.rem)
+		}
+	}
+
+	at := date(2019, 1, 1)
+	for _, period := range []*Timespan{nil, {}, {Months: -1}, {Days: 1, Duration: -24 * time.Hour}} {
+		if _, _, err := (&Timespan{Days: 3}).DivModAt(period, at); err == nil {
+			t.Errorf("DivModAt(%v, %v) returned no error", period, at)
+		}
+
+		if _, err := (&Timespan{Days: 3}).ModAt(period, at); err == nil {
+			t.Errorf("ModAt(%v, %v) returned no error", period, at)
+		}
+	}
+}
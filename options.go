@@ -0,0 +1,170 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A ParseOption alters the behavior of ParseTimespanWith.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	stickyDuration bool
+	bounds         *Timespan
+	skipEmpty      bool
+}
+
+// StickyDurationSign returns a ParseOption that applies the sticky sign rules
+// used for periods to the trailing time.Duration section as well. Instead of
+// being handed to time.ParseDuration as a whole, the duration is split at
+// each explicit sign and every piece inherits the most recently stated sign.
+//
+// Under this option, "1h-30m" (which time.ParseDuration rejects) is 30
+// minutes, "-1h30m" is -90 minutes, and "-1D2h" is {Days: -1, Duration: -2h}
+// rather than {Days: -1, Duration: 2h}.
+func StickyDurationSign() ParseOption {
+	return func(o *parseOptions) {
+		o.stickyDuration = true
+	}
+}
+
+// Bounds returns a ParseOption that rejects any Timespan with a field whose
+// magnitude exceeds that of the corresponding field in limits; e.g. with
+// limits of {Years: 10, Days: 3650}, both "11Y" and "-11Y" are rejected. The
+// Days limit applies to the total of weeks and days, so "600W" exceeds a Days
+// limit of 3650 even though its Days field is zero. A zero field in limits
+// imposes no limit on that field. The error for a violated limit is a
+// *ParseError of type OutOfBoundsErr naming the field and its limit.
+func Bounds(limits *Timespan) ParseOption {
+	return func(o *parseOptions) {
+		o.bounds = limits
+	}
+}
+
+// SkipEmpty returns a ParseOption causing ParseList to ignore empty (or
+// all-whitespace) elements rather than reporting them as errors. It has no
+// effect on ParseTimespanWith.
+func SkipEmpty() ParseOption {
+	return func(o *parseOptions) {
+		o.skipEmpty = true
+	}
+}
+
+// ParseTimespanWith is like ParseTimespan but its behavior may be altered by
+// the given options. With no options, it is identical to ParseTimespan.
+func ParseTimespanWith(s string, opts ...ParseOption) (*Timespan, error) {
+	po := newParseOptions(opts)
+
+	ts := &Timespan{}
+	if err := parseTimespanInto(s, ts, po); err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+func newParseOptions(opts []ParseOption) *parseOptions {
+	po := &parseOptions{}
+	for _, opt := range opts {
+		opt(po)
+	}
+	return po
+}
+
+// parseDuration parses the duration section of a Timespan string where sign
+// is the sign in effect from any preceding periods.
+func (o *parseOptions) parseDuration(
+	s string, sign int,
+) (time.Duration, error) {
+	if !o.stickyDuration {
+		return time.ParseDuration(s)
+	}
+
+	var total int64
+
+	for rest := s; rest != ""; {
+		switch rest[0] {
+		case '-':
+			sign, rest = -1, rest[1:]
+		case '+':
+			sign, rest = 1, rest[1:]
+		}
+
+		n := strings.IndexAny(rest, "+-")
+		if n < 0 {
+			n = len(rest)
+		}
+
+		// Each piece is unsigned so d is never negative here.
+		d, err := time.ParseDuration(rest[:n])
+		if err != nil {
+			return 0, err
+		}
+
+		var ok bool
+		if total, ok = addInt64(total, int64(sign)*int64(d)); !ok {
+			return 0, fmt.Errorf("time: duration %q overflows", s)
+		}
+
+		rest = rest[n:]
+	}
+
+	return time.Duration(total), nil
+}
+
+// checkBounds returns an OutOfBoundsErr if any field of ts exceeds the limits
+// set by Bounds.
+func (o *parseOptions) checkBounds(ts *Timespan) *ParseError {
+	if o.bounds == nil {
+		return nil
+	}
+
+	for _, f := range []struct {
+		name       string
+		val, limit int64
+	}{
+		{"years", int64(ts.Years), int64(o.bounds.Years)},
+		{"months", int64(ts.Months), int64(o.bounds.Months)},
+		{"weeks", int64(ts.Weeks), int64(o.bounds.Weeks)},
+		{"days", int64(ts.days()), int64(o.bounds.Days)},
+		{"duration", int64(ts.Duration), int64(o.bounds.Duration)},
+	} {
+		if f.limit < 0 {
+			f.limit = -f.limit
+		}
+
+		// A zero limit is no limit; -math.MinInt64 overflows back to
+		// itself but no value can exceed its magnitude anyway.
+		if f.limit <= 0 {
+			continue
+		}
+
+		if f.val > f.limit || f.val < -f.limit {
+			if f.name == "duration" {
+				return timespanError(OutOfBoundsErr, "duration %v exceeds limit of %v",
+					time.Duration(f.val), time.Duration(f.limit))
+			}
+			return timespanError(OutOfBoundsErr, "%s %d exceeds limit of %d",
+				f.name, f.val, f.limit)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimespanWithStickyDurationSign(t *testing.T) {
+	for _, td := range []testdata{
+		{"1h-30m", &Timespan{Duration: 30 * time.Minute}, NoErr},
+		{"1h30m", &Timespan{Duration: 90 * time.Minute}, NoErr},
+		{"-1h30m", &Timespan{Duration: -90 * time.Minute}, NoErr},
+		{"-1h+30m", &Timespan{Duration: -30 * time.Minute}, NoErr},
+		{"2h-30m15s+5s", &Timespan{Duration: 89*time.Minute + 50*time.Second}, NoErr},
+		{"1D-2h30m", &Timespan{Days: 1, Duration: -150 * time.Minute}, NoErr},
+		{"-1D2h", &Timespan{Days: -1, Duration: -2 * time.Hour}, NoErr},
+		{"-1D+2h", &Timespan{Days: -1, Duration: 2 * time.Hour}, NoErr},
+		{"1Y2M", &Timespan{Years: 1, Months: 2}, NoErr},
+		{"1h-", nil, BadDurationErr},
+		{"1h--30m", nil, BadDurationErr},
+		{"1D2x", nil, BadDurationErr},
+		{"2562047h-1h2562047h", nil, BadDurationErr},
+	} {
+		got, err := ParseTimespanWith(td.str, StickyDurationSign())
+		if gt := errType(err); gt != td.etype {
+			t.Errorf("ParseTimespanWith(%q, StickyDurationSign()) error type == %v; Wanted %v (err: %v)", td.str, gt, td.etype, err)
+			continue
+		}
+
+		if !got.Equal(td.want) {
+			t.Errorf("ParseTimespanWith(%q, StickyDurationSign()) == %#v; Wanted %#v", td.str, got, td.want)
+		}
+	}
+}
+
+func TestParseTimespanWithNoOptions(t *testing.T) {
+	for _, s := range []string{"1Y2M3W4D5h6m", "-1D2h", "1h30m", "1h-30m", "", "1X"} {
+		want, werr := ParseTimespan(s)
+		got, gerr := ParseTimespanWith(s)
+
+		if errType(gerr) != errType(werr) || !got.Equal(want) {
+			t.Errorf("ParseTimespanWith(%q) == (%v, %v); Wanted (%v, %v)", s, got, gerr, want, werr)
+		}
+	}
+}
+
+// errType returns the ErrType for err; NoErr if err is nil or -1 if err is
+// not a *ParseError.
+func errType(err error) ErrType {
+	if err == nil {
+		return NoErr
+	}
+
+	if pe, ok := err.(*ParseError); ok {
+		return pe.Type()
+	}
+
+	return -1
+}
+
+func TestParseTimespanWithBounds(t *testing.T) {
+	limits := &Timespan{Years: 10, Days: 3650, Duration: 48 * time.Hour}
+
+	for _, td := range []testdata{
+		{"10Y", &Timespan{Years: 10}, NoErr},
+		{"-10Y", &Timespan{Years: -10}, NoErr},
+		{"11Y", nil, OutOfBoundsErr},
+		{"-11Y", nil, OutOfBoundsErr},
+		{"9999999Y", nil, OutOfBoundsErr},
+		{"1200M", &Timespan{Months: 1200}, NoErr},
+		{"3650D", &Timespan{Days: 3650}, NoErr},
+		{"521W3D", &Timespan{Weeks: 521, Days: 3}, NoErr},
+		{"600W", nil, OutOfBoundsErr},
+		{"-521W4D", nil, OutOfBoundsErr},
+		{"48h", &Timespan{Duration: 48 * time.Hour}, NoErr},
+		{"-48h1ns", nil, OutOfBoundsErr},
+		{"1D49h", nil, OutOfBoundsErr},
+		{"1D2W", nil, MagnOutOfOrderErr},
+	} {
+		got, err := ParseTimespanWith(td.str, Bounds(limits))
+		if gt := errType(err); gt != td.etype {
+			t.Errorf("ParseTimespanWith(%q, Bounds(%v)) error type == %v; Wanted %v (err: %v)", td.str, limits, gt, td.etype, err)
+			continue
+		}
+
+		if !got.Equal(td.want) {
+			t.Errorf("ParseTimespanWith(%q, Bounds(%v)) == %#v; Wanted %#v", td.str, limits, got, td.want)
+		}
+	}
+
+	_, err := ParseTimespanWith("600W", Bounds(limits))
+	if want := `parsing Timespan "600W": days 4200 exceeds limit of 3650`; err == nil || err.Error() != want {
+		t.Errorf("ParseTimespanWith(%q, Bounds(%v)) error == %v; Wanted %q", "600W", limits, err, want)
+	}
+
+	if got, err := ParseTimespanWith("5W", Bounds(&Timespan{Weeks: 4})); errType(err) != OutOfBoundsErr {
+		t.Errorf("ParseTimespanWith(%q) with a weeks limit == (%v, %v); Wanted %v", "5W", got, err, OutOfBoundsErr)
+	}
+
+	if got, err := ParseTimespanWith("9999999Y", Bounds(&Timespan{Years: -10, Days: minInt})); errType(err) != OutOfBoundsErr {
+		t.Errorf("ParseTimespanWith(%q) with negative limits == (%v, %v); Wanted %v", "9999999Y", got, err, OutOfBoundsErr)
+	}
+}
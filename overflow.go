@@ -0,0 +1,127 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrOverflow is returned by AddChecked and ScaleChecked when any member of
+// their result would overflow.
+var ErrOverflow = errors.New("timespan: arithmetic overflow")
+
+// AddChecked is like Add except that, if any member of the result overflows
+// (an int for Years, Months, Weeks and Days or an int64 count of nanoseconds
+// for Duration), a nil Timespan and ErrOverflow are returned instead of a
+// result that has silently wrapped around.
+func (ts *Timespan) AddChecked(ots *Timespan) (*Timespan, error) {
+	a, b := ts.orZero(), ots.orZero()
+
+	y, ok1 := addInt(a.Years, b.Years)
+	m, ok2 := addInt(a.Months, b.Months)
+	w, ok3 := addInt(a.Weeks, b.Weeks)
+	d, ok4 := addInt(a.Days, b.Days)
+	n, ok5 := addInt64(int64(a.Duration), int64(b.Duration))
+
+	if !(ok1 && ok2 && ok3 && ok4 && ok5) {
+		return nil, ErrOverflow
+	}
+
+	return &Timespan{
+		Years: y, Months: m, Weeks: w, Days: d, Duration: time.Duration(n),
+	}, nil
+}
+
+// ScaleChecked is like MulInt except that, if any member of the result
+// overflows, a nil Timespan and ErrOverflow are returned instead of a result
+// that has silently wrapped around.
+func (ts *Timespan) ScaleChecked(n int) (*Timespan, error) {
+	v := ts.orZero()
+
+	y, ok1 := mulInt(v.Years, n)
+	m, ok2 := mulInt(v.Months, n)
+	w, ok3 := mulInt(v.Weeks, n)
+	d, ok4 := mulInt(v.Days, n)
+	ns, ok5 := mulInt64(int64(v.Duration), int64(n))
+
+	if !(ok1 && ok2 && ok3 && ok4 && ok5) {
+		return nil, ErrOverflow
+	}
+
+	return &Timespan{
+		Years: y, Months: m, Weeks: w, Days: d, Duration: time.Duration(ns),
+	}, nil
+}
+
+const (
+	maxInt = int(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// addInt returns a+b and true, or false if the sum overflows an int.
+func addInt(a, b int) (int, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// mulInt returns a*b and true, or false if the product overflows an int.
+func mulInt(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	if (a == -1 && b == minInt) || (b == -1 && a == minInt) {
+		return 0, false
+	}
+
+	c := a * b
+	if c/b != a {
+		return 0, false
+	}
+	return c, true
+}
+
+// addInt64 returns a+b and true, or false if the sum overflows an int64.
+func addInt64(a, b int64) (int64, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// mulInt64 returns a*b and true, or false if the product overflows an int64.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+
+	c := a * b
+	if c/b != a {
+		return 0, false
+	}
+	return c, true
+}
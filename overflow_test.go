@@ -0,0 +1,162 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseTimespanOverflow(t *testing.T) {
+	data := []testdata{
+		{str: "99999999999999999999Y", etype: CoefOverflowErr},
+		{str: "1Y-99999999999999999999M", etype: CoefOverflowErr},
+		{str: strconv.Itoa(maxInt/7+1) + "W", etype: CoefOverflowErr},
+		{str: "-" + strconv.Itoa(maxInt/7+1) + "W", etype: CoefOverflowErr},
+		{str: strconv.Itoa(maxInt/7) + "W2D", etype: CoefOverflowErr},
+	}
+
+	for _, td := range data {
+		_, err := ParseTimespan(td.str)
+
+		pe, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("ParseTimespan(%q) error == %v; Wanted a %v ParseError", td.str, err, td.etype)
+			continue
+		}
+
+		if pe.Type() != td.etype {
+			t.Errorf("ParseTimespan(%q) error type == %v; Wanted %v", td.str, pe.Type(), td.etype)
+		}
+
+		if pe.Input() != td.str {
+			t.Errorf("Input mismatch for ParseError from %q: got %q", td.str, pe.Input())
+		}
+	}
+
+	// The largest number of whole weeks still fits.
+	s := strconv.Itoa(maxInt/7) + "W"
+	if ts, err := ParseTimespan(s); err != nil || ts.Weeks != maxInt/7 {
+		t.Errorf("ParseTimespan(%q) == (%v, %v); Wanted %d weeks", s, ts, err, maxInt/7)
+	}
+}
+
+func TestAddMulInt(t *testing.T) {
+	cases := []struct {
+		a, b  int
+		sum   bool
+		prod  bool
+		wantS int
+		wantP int
+	}{
+		{1, 2, true, true, 3, 2},
+		{maxInt, 1, false, true, 0, maxInt},
+		{minInt, -1, false, false, 0, 0},
+		{maxInt, minInt, true, false, -1, 0},
+		{maxInt / 2, 2, true, true, maxInt/2 + 2, maxInt - 1},
+		{maxInt/2 + 1, 2, true, false, maxInt/2 + 3, 0},
+		{minInt / 2, 2, true, true, minInt/2 + 2, minInt},
+		{0, minInt, true, true, minInt, 0},
+	}
+
+	for _, tc := range cases {
+		if got, ok := addInt(tc.a, tc.b); ok != tc.sum || ok && got != tc.wantS {
+			t.Errorf("addInt(%d, %d) == (%d, %v); Wanted (%d, %v)", tc.a, tc.b, got, ok, tc.wantS, tc.sum)
+		}
+
+		if got, ok := mulInt(tc.a, tc.b); ok != tc.prod || ok && got != tc.wantP {
+			t.Errorf("mulInt(%d, %d) == (%d, %v); Wanted (%d, %v)", tc.a, tc.b, got, ok, tc.wantP, tc.prod)
+		}
+	}
+}
+
+func TestTimespanAddChecked(t *testing.T) {
+	big := &Timespan{Years: maxInt}
+	long := &Timespan{Duration: math.MaxInt64}
+
+	cases := []struct {
+		ts, ots *Timespan
+		want    *Timespan
+	}{
+		{&Timespan{1, 2, 0, 3, 4}, &Timespan{5, 6, 0, 7, 8}, &Timespan{6, 8, 0, 10, 12}},
+		{&Timespan{Weeks: 2, Days: 3}, &Timespan{Weeks: -1, Days: 5}, &Timespan{Weeks: 1, Days: 8}},
+		{big, &Timespan{Years: -1}, &Timespan{Years: maxInt - 1}},
+		{nil, long, long},
+		{big, &Timespan{Years: 1}, nil},
+		{&Timespan{Months: minInt}, &Timespan{Months: -1}, nil},
+		{&Timespan{Days: maxInt}, &Timespan{Days: maxInt}, nil},
+		{&Timespan{Weeks: maxInt}, &Timespan{Weeks: 1}, nil},
+		{long, &Timespan{Duration: 1}, nil},
+		{&Timespan{Duration: math.MinInt64}, &Timespan{Duration: -1}, nil},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.ts.AddChecked(tc.ots)
+
+		if tc.want == nil {
+			if err != ErrOverflow || got != nil {
+				t.Errorf("(%v).AddChecked(%v) == (%v, %v); Wanted (nil, %v)", tc.ts, tc.ots, got, err, ErrOverflow)
+			}
+			continue
+		}
+
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("(%v).AddChecked(%v) == (%v, %v); Wanted (%v, nil)", tc.ts, tc.ots, got, err, tc.want)
+		}
+
+		if unchecked := tc.ts.Add(tc.ots); !unchecked.Equal(got) {
+			t.Errorf("(%v).AddChecked(%v) == %v; but Add gives %v", tc.ts, tc.ots, got, unchecked)
+		}
+	}
+}
+
+func TestTimespanScaleChecked(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		n    int
+		want *Timespan
+	}{
+		{&Timespan{1, 2, 0, 3, 4}, 3, &Timespan{3, 6, 0, 9, 12}},
+		{&Timespan{1, -2, 0, 3, -4}, -1, &Timespan{-1, 2, 0, -3, 4}},
+		{&Timespan{Weeks: 2, Days: 1}, 3, &Timespan{Weeks: 6, Days: 3}},
+		{&Timespan{Years: maxInt}, 0, &Timespan{}},
+		{nil, maxInt, &Timespan{}},
+		{&Timespan{Years: maxInt/2 + 1}, 2, nil},
+		{&Timespan{Months: minInt}, -1, nil},
+		{&Timespan{Days: 2}, maxInt, nil},
+		{&Timespan{Weeks: minInt}, 2, nil},
+		{&Timespan{Duration: 365 * 24 * time.Hour}, 300, nil},
+		{&Timespan{Duration: math.MinInt64}, -1, nil},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.ts.ScaleChecked(tc.n)
+
+		if tc.want == nil {
+			if err != ErrOverflow || got != nil {
+				t.Errorf("(%v).ScaleChecked(%d) == (%v, %v); Wanted (nil, %v)", tc.ts, tc.n, got, err, ErrOverflow)
+			}
+			continue
+		}
+
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("(%v).ScaleChecked(%d) == (%v, %v); Wanted (%v, nil)", tc.ts, tc.n, got, err, tc.want)
+		}
+	}
+}
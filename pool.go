@@ -0,0 +1,47 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "sync"
+
+var tsPool = sync.Pool{
+	New: func() interface{} { return new(Timespan) },
+}
+
+// GetTimespan returns a pointer to a zero value Timespan, either recycled from
+// a package-level pool or newly allocated if the pool is empty. Combined with
+// ParseTimespanInto, this reduces allocator pressure in hot paths that parse
+// many Timespan strings.
+//
+// Each Timespan acquired from GetTimespan should be released by a call to
+// PutTimespan once it is no longer needed.
+func GetTimespan() *Timespan {
+	return tsPool.Get().(*Timespan)
+}
+
+// PutTimespan zeroes ts and returns it to the pool used by GetTimespan.
+// Since ts may be handed out again by a later call to GetTimespan, it must
+// never be used (or retained) after calling PutTimespan. A nil ts is
+// ignored.
+func PutTimespan(ts *Timespan) {
+	if ts == nil {
+		return
+	}
+
+	*ts = Timespan{}
+	tsPool.Put(ts)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	ts := GetTimespan()
+	if !ts.IsZero() {
+		t.Fatalf("GetTimespan() returned non-zero Timespan: %+v", ts)
+	}
+
+	if err := ParseTimespanInto("1Y2M3D4h", ts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	if !ts.Equal(want) {
+		t.Errorf("ParseTimespanInto mismatch: Got %+v; Wanted %+v", ts, want)
+	}
+
+	PutTimespan(ts)
+
+	if !ts.IsZero() {
+		t.Errorf("PutTimespan failed to zero its argument: %+v", ts)
+	}
+
+	// Must not panic
+	PutTimespan(nil)
+}
+
+func TestParseTimespanIntoError(t *testing.T) {
+	want := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	got := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+
+	if err := ParseTimespanInto("1D2W", got); err == nil {
+		t.Fatal("ParseTimespanInto failed to return an error for invalid input")
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("ParseTimespanInto modified its destination on error: Got %+v; Wanted %+v", got, want)
+	}
+}
+
+var benchSpans = []string{"1Y2M3W4D5h6m7s89ms", "18M", "-1W+2D", "90D", "1h30m"}
+
+func TestParseTimespanIntoAllocs(t *testing.T) {
+	var ts Timespan
+
+	for _, s := range benchSpans {
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := ParseTimespanInto(s, &ts); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		if allocs != 0 {
+			t.Errorf("ParseTimespanInto(%q) allocations: Got %v; Wanted 0", s, allocs)
+		}
+	}
+}
+
+func BenchmarkParseTimespan(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseTimespan(benchSpans[i%len(benchSpans)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseTimespanPooled(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ts := GetTimespan()
+		if err := ParseTimespanInto(benchSpans[i%len(benchSpans)], ts); err != nil {
+			b.Fatal(err)
+		}
+		PutTimespan(ts)
+	}
+}
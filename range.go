@@ -0,0 +1,124 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strings"
+	"time"
+)
+
+const rangeSep = ".."
+
+// A Range is a range of Timespans, typically acquired from ParseRange. A nil
+// Lo or Hi indicates that end of the Range is unbounded.
+type Range struct {
+	Lo *Timespan
+	Hi *Timespan
+}
+
+// Contains returns true if the Timespan ts, evaluated at Time at, falls
+// within r. Both bounds are inclusive and a nil bound is unbounded.
+func (r Range) Contains(ts *Timespan, at time.Time) bool {
+	if r.Lo != nil && ts.CompareAt(r.Lo, at) < 0 {
+		return false
+	}
+
+	if r.Hi != nil && ts.CompareAt(r.Hi, at) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// String renders r in a form parseable by ParseRange.
+func (r Range) String() string {
+	return boundString(r.Lo) + rangeSep + boundString(r.Hi)
+}
+
+// boundString renders a single Range bound. Since a zero Timespan renders
+// as an empty string, which would be mistaken for an unbounded end, it is
+// rendered here as "0s" instead.
+func boundString(ts *Timespan) string {
+	switch {
+	case ts == nil:
+		return ""
+	case ts.IsZero():
+		return "0s"
+	default:
+		return ts.String()
+	}
+}
+
+// ParseRange parses a range of Timespans expressed as two Timespan strings
+// separated by "..", e.g. "1Y..2Y". Either bound may be omitted to indicate
+// an open (i.e. unbounded) end of the range; "..6M" has no lower bound while
+// "3D.." has no upper bound. An omitted bound is returned as nil.
+//
+// Each bound is parsed using ParseTimespan. Since the relative order of two
+// Timespans depends on a point in time, ParseRange does not verify that lo
+// is not greater than hi; use ParseRangeAt for that.
+func ParseRange(s string) (lo, hi *Timespan, err error) {
+	i := strings.Index(s, rangeSep)
+	if i < 0 {
+		return nil, nil, timespanError(BadRangeErr, "missing %q separator", rangeSep).withTimespan(s)
+	}
+
+	ls, hs := s[:i], s[i+len(rangeSep):]
+
+	if strings.Contains(hs, rangeSep) {
+		return nil, nil, timespanError(BadRangeErr, "multiple %q separators", rangeSep).withTimespan(s)
+	}
+
+	if lo, err = parseBound(ls, s); err != nil {
+		return nil, nil, err
+	}
+
+	if hi, err = parseBound(hs, s); err != nil {
+		return nil, nil, err
+	}
+
+	return lo, hi, nil
+}
+
+// ParseRangeAt is like ParseRange but, when both bounds are present, it also
+// verifies that lo is not greater than hi when evaluated at Time t.
+func ParseRangeAt(s string, t time.Time) (lo, hi *Timespan, err error) {
+	if lo, hi, err = ParseRange(s); err != nil {
+		return nil, nil, err
+	}
+
+	if lo != nil && hi != nil && lo.CompareAt(hi, t) > 0 {
+		return nil, nil, timespanError(RangeOrderErr, "lower bound %v exceeds upper bound %v at %v", lo, hi, t).withTimespan(s)
+	}
+
+	return lo, hi, nil
+}
+
+// parseBound parses one side of the range string s, returning nil for an
+// omitted bound.
+func parseBound(b, s string) (*Timespan, error) {
+	if b == "" {
+		return nil, nil
+	}
+
+	ts, err := ParseTimespan(b)
+	if pe, ok := err.(*ParseError); ok {
+		return nil, pe.withTimespan(s)
+	}
+
+	return ts, err
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		str    string
+		lo, hi *Timespan
+	}{
+		{"1Y..2Y", &Timespan{Years: 1}, &Timespan{Years: 2}},
+		{"..6M", nil, &Timespan{Months: 6}},
+		{"3D..", &Timespan{Days: 3}, nil},
+		{"..", nil, nil},
+		{"1.5h..2h", &Timespan{Duration: 90 * time.Minute}, &Timespan{Duration: 2 * time.Hour}},
+		{"2Y..1Y", &Timespan{Years: 2}, &Timespan{Years: 1}},
+	}
+
+	for _, tc := range cases {
+		lo, hi, err := ParseRange(tc.str)
+		if err != nil {
+			t.Errorf("ParseRange(%q) returned error: %v", tc.str, err)
+			continue
+		}
+
+		if !lo.Equal(tc.lo) || !hi.Equal(tc.hi) {
+			t.Errorf("ParseRange(%q) == (%v, %v); Wanted (%v, %v)", tc.str, lo, hi, tc.lo, tc.hi)
+		}
+	}
+}
+
+func TestParseRangeBad(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	data := []testdata{
+		{str: "1Y", etype: BadRangeErr},
+		{str: "1D..2D..3D", etype: BadRangeErr},
+		{str: "1D2W..", etype: MagnOutOfOrderErr},
+		{str: "..18h9", etype: BadDurationErr},
+		{str: "2Y..1Y", etype: RangeOrderErr},
+		{str: "30D..1M", etype: RangeOrderErr},
+	}
+
+	for _, td := range data {
+		_, _, err := ParseRangeAt(td.str, at)
+		if err == nil {
+			t.Errorf("No error found parsing invalid range %q: wanted:%v", td.str, td.etype)
+			continue
+		}
+
+		if pe, ok := err.(*ParseError); !ok {
+			t.Errorf("Error returned while parsing invalid range %q is not a ParseError: %v", td.str, err)
+		} else if pe.Type() != td.etype || pe.Input() != td.str {
+			t.Errorf("Error mismatch parsing invalid range %q: got (%v, %q); wanted (%v, %q)", td.str, pe.Type(), pe.Input(), td.etype, td.str)
+		}
+	}
+
+	// "1M" and "28D" are equivalent in February
+	if _, _, err := ParseRangeAt("1M..28D", at); err != nil {
+		t.Errorf("ParseRangeAt(%q, %v) returned error: %v", "1M..28D", at, err)
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		rng  string
+		ts   *Timespan
+		want bool
+	}{
+		{"1Y..2Y", &Timespan{Months: 18}, true},
+		{"1Y..2Y", &Timespan{Years: 1}, true},
+		{"1Y..2Y", &Timespan{Years: 2}, true},
+		{"1Y..2Y", &Timespan{Months: 11}, false},
+		{"1Y..2Y", &Timespan{Years: 2, Duration: 1}, false},
+		{"..6M", &Timespan{Years: -5}, true},
+		{"..6M", &Timespan{Months: 7}, false},
+		{"3D..", &Timespan{Years: 100}, true},
+		{"3D..", &Timespan{Duration: 71 * time.Hour}, false},
+		{"..", nil, true},
+		{"1M..28D", &Timespan{Duration: 28 * 24 * time.Hour}, true},
+	}
+
+	for _, tc := range cases {
+		lo, hi, err := ParseRange(tc.rng)
+		if err != nil {
+			t.Errorf("ParseRange(%q) returned error: %v", tc.rng, err)
+			continue
+		}
+
+		if got := (Range{lo, hi}).Contains(tc.ts, at); got != tc.want {
+			t.Errorf("Range(%q).Contains(%v, %v) == %v; Wanted %v", tc.rng, tc.ts, at, got, tc.want)
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	cases := []struct {
+		rng  Range
+		want string
+	}{
+		{Range{&Timespan{Years: 1}, &Timespan{Years: 2}}, "1Y..2Y"},
+		{Range{nil, &Timespan{Months: 6}}, "..6M"},
+		{Range{&Timespan{Days: 3}, nil}, "3D.."},
+		{Range{}, ".."},
+		{Range{&Timespan{}, &Timespan{Duration: 90 * time.Minute}}, "0s..1h30m0s"},
+	}
+
+	for _, tc := range cases {
+		got := tc.rng.String()
+		if got != tc.want {
+			t.Errorf("Range%+v.String() == %q; Wanted %q", tc.rng, got, tc.want)
+		}
+
+		lo, hi, err := ParseRange(got)
+		if err != nil {
+			t.Errorf("ParseRange(%q) returned error: %v", got, err)
+			continue
+		}
+
+		if !lo.Equal(tc.rng.Lo) || !hi.Equal(tc.rng.Hi) {
+			t.Errorf("Range %q failed to round-trip: Got (%v, %v); Wanted (%v, %v)", got, lo, hi, tc.rng.Lo, tc.rng.Hi)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RenderOptions controls how StringWith renders a Timespan. The zero value
+// renders the same as String.
+//
+// Only magnitudes understood by the parsers are offered; there are no
+// options for quarters, decades or centuries since neither ParseTimespan nor
+// ParseUnambiguous would accept them.
+type RenderOptions struct {
+	// Weeks moves whole weeks from Days into the rendered weeks, leaving
+	// any remaining days; e.g. 17 Days is rendered as "2W3D" instead of
+	// "17D". The Weeks member is always rendered in weeks regardless.
+	Weeks bool
+
+	// TrimZeroUnits omits zero-valued units from the Duration; e.g. one hour
+	// is rendered as "1h" instead of "1h0m0s".
+	TrimZeroUnits bool
+
+	// Verbose renders using the space separated units accepted by
+	// ParseUnambiguous; e.g. "1yr 2mo 3d 4h 5min 0s" instead of "1Y2M3D4h5m0s".
+	Verbose bool
+}
+
+// renderUnits holds the calendar units used by StringWith in compact and
+// verbose form, respectively.
+var renderUnits = map[rune][2]string{
+	'Y': {"Y", "yr"},
+	'M': {"M", "mo"},
+	'W': {"W", "wk"},
+	'D': {"D", "d"},
+}
+
+// StringWith renders ts according to opts. The result may be parsed back into
+// an equal Timespan using ParseUnambiguous if opts.Verbose is set or
+// ParseTimespan otherwise; if opts.Weeks is set, the result instead has any
+// whole weeks from Days moved into Weeks, which is applied identically.
+// Where a positive calendar component follows a negative one, it is rendered
+// with an explicit "+" so that the parser's sticky signs don't carry the
+// negative sign forward; the Duration's sign is always stated on its own.
+//
+// A zero Timespan is rendered as an empty string and a nil Timespan as
+// "<nil>".
+func (ts *Timespan) StringWith(opts RenderOptions) string {
+	if ts == nil {
+		return "<nil>"
+	}
+
+	var parts []string
+	unit := 0
+	if opts.Verbose {
+		unit = 1
+	}
+
+	weeks, days := ts.Weeks, ts.Days
+	if opts.Weeks {
+		weeks, days = weeks+days/7, days%7
+	}
+
+	negative := false
+	for _, c := range []struct {
+		mag rune
+		val int
+	}{{'Y', ts.Years}, {'M', ts.Months}, {'W', weeks}, {'D', days}} {
+		if c.val == 0 {
+			continue
+		}
+
+		s := strconv.Itoa(c.val) + renderUnits[c.mag][unit]
+		if c.val > 0 && negative {
+			s = "+" + s
+		}
+		negative = c.val < 0
+
+		parts = append(parts, s)
+	}
+
+	if ts.Duration != 0 {
+		parts = append(parts, renderDuration(ts.Duration.String(), opts))
+	}
+
+	if opts.Verbose {
+		return strings.Join(parts, " ")
+	}
+
+	return strings.Join(parts, "")
+}
+
+// renderDuration rewrites ds, the output of time.Duration.String, according
+// to opts.
+func renderDuration(ds string, opts RenderOptions) string {
+	if !opts.TrimZeroUnits && !opts.Verbose {
+		return ds
+	}
+
+	sign := ""
+	if ds[0] == '-' {
+		sign, ds = "-", ds[1:]
+	}
+
+	var parts []string
+	for ds != "" {
+		i := strings.IndexFunc(ds, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+		j := strings.IndexAny(ds[i:], "0123456789")
+		if j < 0 {
+			j = len(ds)
+		} else {
+			j += i
+		}
+
+		num, unit := ds[:i], ds[i:j]
+		ds = ds[j:]
+
+		if opts.TrimZeroUnits && num == "0" {
+			continue
+		}
+
+		if opts.Verbose && unit == "m" {
+			unit = "min"
+		}
+
+		parts = append(parts, num+unit)
+	}
+
+	if opts.Verbose {
+		return sign + strings.Join(parts, " ")
+	}
+
+	return sign + strings.Join(parts, "")
+}
+
+// StringComponents returns each non-zero component of ts rendered on its own,
+// in the order Years, Months, Weeks, Days and Duration; e.g. ["1Y", "2M",
+// "14D", "1h30m"]. Zero-valued units are trimmed from the Duration (as with
+// RenderOptions.TrimZeroUnits) and each element may be parsed individually
+// by ParseTimespan. A nil or zero Timespan yields an empty slice.
+func (ts *Timespan) StringComponents() []string {
+	var parts []string
+
+	for _, c := range ts.Decompose() {
+		parts = append(parts, c.StringWith(RenderOptions{TrimZeroUnits: true}))
+	}
+
+	return parts
+}
+
+// StringPreservingWeeks is like String except that Days are rendered as whole
+// weeks plus any remaining days; e.g. 21 days is "3W" and 23 days is "3W2D".
+// It is shorthand for ts.StringWith(RenderOptions{Weeks: true}) and its result
+// is parseable by ParseTimespan (which keeps the weeks in the Weeks member).
+func (ts *Timespan) StringPreservingWeeks() string {
+	return ts.StringWith(RenderOptions{Weeks: true})
+}
+
+// Canonical renders ts in a normalized form suitable for use as a map key or
+// for deduplication. Each distinct combination of member values has exactly
+// one canonical form, so a.Equal(b) if and only if a.Canonical() ==
+// b.Canonical(), and ParseTimespan(ts.Canonical()) is always equal to ts.
+//
+// Components are rendered in order from Years to Duration as with String,
+// with signs stated only where the parser requires them and zero-valued
+// units trimmed from the Duration (e.g. "1D1h" rather than "1D1h0m0s").
+// Weeks and Days are kept as given, so "1W" and "7D" remain distinct, as
+// they are for Equal. Unlike String, a zero Timespan is rendered as "0s".
+// A nil Timespan, which is not Equal to a zero one, is rendered as "<nil>".
+func (ts *Timespan) Canonical() string {
+	if s := ts.StringWith(RenderOptions{TrimZeroUnits: true}); s != "" {
+		return s
+	}
+
+	return "0s"
+}
+
+// GoString implements fmt.GoStringer so that the "%#v" verb renders ts as a
+// Go expression that reconstructs an equal Timespan using only this
+// package's exported API; e.g. timespan.MustParseTimespan("1Y6M14D"). A zero
+// Timespan is rendered as timespan.New(0, 0, 0, 0) and a nil Timespan as
+// (*timespan.Timespan)(nil).
+func (ts *Timespan) GoString() string {
+	switch {
+	case ts == nil:
+		return "(*timespan.Timespan)(nil)"
+	case ts.IsZero():
+		return "timespan.New(0, 0, 0, 0)"
+	default:
+		return "timespan.MustParseTimespan(" + strconv.Quote(ts.Canonical()) + ")"
+	}
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimespanStringWith(t *testing.T) {
+	var (
+		weeks   = RenderOptions{Weeks: true}
+		trim    = RenderOptions{TrimZeroUnits: true}
+		verbose = RenderOptions{Verbose: true}
+		all     = RenderOptions{Weeks: true, TrimZeroUnits: true, Verbose: true}
+	)
+
+	cases := []struct {
+		ts   *Timespan
+		opts RenderOptions
+		want string
+	}{
+		{&Timespan{1, 2, 0, 17, 4 * time.Hour}, RenderOptions{}, "1Y2M17D4h0m0s"},
+		{&Timespan{1, 2, 0, 17, 4 * time.Hour}, weeks, "1Y2M2W3D4h0m0s"},
+		{&Timespan{1, 2, 0, 17, 4 * time.Hour}, trim, "1Y2M17D4h"},
+		{&Timespan{1, 2, 0, 17, 4 * time.Hour}, verbose, "1yr 2mo 17d 4h 0min 0s"},
+		{&Timespan{1, 2, 0, 17, 4 * time.Hour}, all, "1yr 2mo 2wk 3d 4h"},
+		{&Timespan{Days: 14}, weeks, "2W"},
+		{&Timespan{Days: -10}, weeks, "-1W-3D"},
+		{&Timespan{Days: 6}, weeks, "6D"},
+		{&Timespan{Years: -1, Months: 2}, RenderOptions{}, "-1Y+2M"},
+		{&Timespan{Years: -1, Months: 2}, verbose, "-1yr +2mo"},
+		{&Timespan{Years: 1, Months: -2, Days: 3}, RenderOptions{}, "1Y-2M+3D"},
+		{&Timespan{Days: -1, Duration: time.Hour + time.Second}, trim, "-1D1h1s"},
+		{&Timespan{Duration: -(90*time.Minute + 500*time.Millisecond)}, verbose, "-1h 30min 0.5s"},
+		{&Timespan{Duration: 1500 * time.Microsecond}, verbose, "1.5ms"},
+		{&Timespan{}, all, ""},
+		{nil, all, "<nil>"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.StringWith(tc.opts); got != tc.want {
+			t.Errorf("(%#v).StringWith(%+v) == %q; Wanted %q", tc.ts, tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanStringWithRoundTrip(t *testing.T) {
+	spans := []*Timespan{
+		{1, 2, 0, 17, 4 * time.Hour},
+		{-1, 2, 0, -17, 4*time.Hour + 3*time.Millisecond},
+		{0, -5, 0, 3, -time.Minute},
+		{2, 0, 0, -8, 0},
+		{0, 0, 0, 0, -36*time.Hour - 1},
+		{0, 0, 0, 21, 59 * time.Second},
+	}
+
+	for _, ts := range spans {
+		for _, opts := range []RenderOptions{
+			{},
+			{Weeks: true},
+			{TrimZeroUnits: true},
+			{Verbose: true},
+			{Weeks: true, TrimZeroUnits: true},
+			{Weeks: true, TrimZeroUnits: true, Verbose: true},
+		} {
+			s := ts.StringWith(opts)
+
+			parse := ParseTimespan
+			if opts.Verbose {
+				parse = ParseUnambiguous
+			}
+
+			got, err := parse(s)
+			if err != nil {
+				t.Errorf("(%#v).StringWith(%+v) == %q; which fails to parse: %v", ts, opts, s, err)
+				continue
+			}
+
+			if !foldedEqual(got, ts) {
+				t.Errorf("(%#v).StringWith(%+v) == %q; which parses to %#v", ts, opts, s, got)
+			}
+		}
+	}
+}
+
+func TestTimespanStringComponents(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want []string
+	}{
+		{&Timespan{1, 2, 0, 14, 90 * time.Minute}, []string{"1Y", "2M", "14D", "1h30m"}},
+		{&Timespan{Years: -1, Months: 2}, []string{"-1Y", "2M"}},
+		{&Timespan{Days: 3, Duration: -time.Second}, []string{"3D", "-1s"}},
+		{&Timespan{Months: 5}, []string{"5M"}},
+		{&Timespan{}, nil},
+		{nil, nil},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.StringComponents()
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("(%v).StringComponents() == %q; Wanted %q", tc.ts, got, tc.want)
+			continue
+		}
+
+		var parts []*Timespan
+		for _, s := range got {
+			ts, err := ParseTimespan(s)
+			if err != nil {
+				t.Errorf("ParseTimespan(%q) failed: %v", s, err)
+				continue
+			}
+			parts = append(parts, ts)
+		}
+
+		if sum, want := Sum(parts...), tc.ts.orZero(); !sum.Equal(&want) {
+			t.Errorf("Sum of parsed (%v).StringComponents() == %v; Wanted %v", tc.ts, sum, &want)
+		}
+	}
+}
+
+func TestTimespanStringPreservingWeeks(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want string
+	}{
+		{&Timespan{Days: 21}, "3W"},
+		{&Timespan{Days: 23}, "3W2D"},
+		{&Timespan{Days: 6}, "6D"},
+		{&Timespan{Days: -23}, "-3W-2D"},
+		{&Timespan{Years: -1, Days: 8}, "-1Y+1W1D"},
+		{&Timespan{1, 2, 0, 14, time.Hour}, "1Y2M2W1h0m0s"},
+		{&Timespan{}, ""},
+		{nil, "<nil>"},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.StringPreservingWeeks()
+		if got != tc.want {
+			t.Errorf("(%#v).StringPreservingWeeks() == %q; Wanted %q", tc.ts, got, tc.want)
+			continue
+		}
+
+		if tc.ts.IsZero() {
+			continue
+		}
+
+		if ts, err := ParseTimespan(got); err != nil || !foldedEqual(ts, tc.ts) {
+			t.Errorf("ParseTimespan(%q) == (%#v, %v); Wanted %#v", got, ts, err, tc.ts)
+		}
+	}
+}
+
+func TestTimespanCanonical(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want string
+	}{
+		{nil, "<nil>"},
+		{&Timespan{}, "0s"},
+		{&Timespan{Weeks: 1}, "1W"},
+		{&Timespan{Days: 7}, "7D"},
+		{&Timespan{Days: 1, Duration: time.Hour}, "1D1h"},
+		{&Timespan{Duration: 90 * time.Second}, "1m30s"},
+		{&Timespan{-1, 2, 0, -3, time.Hour}, "-1Y+2M-3D1h"},
+		{&Timespan{0, 0, -2, 1, -time.Millisecond}, "-2W+1D-1ms"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.Canonical(); got != tc.want {
+			t.Errorf("(%#v).Canonical() == %q; Wanted %q", tc.ts, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanCanonicalProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// field returns a small value (often zero or a sign flip of a common
+	// value) so that randomly generated spans frequently collide.
+	field := func() int {
+		switch rng.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return rng.Intn(5) - 2
+		default:
+			return rng.Intn(2000001) - 1000000
+		}
+	}
+
+	duration := func() time.Duration {
+		switch rng.Intn(5) {
+		case 0:
+			return 0
+		case 1:
+			return time.Duration(rng.Intn(5)-2) * time.Hour
+		case 2:
+			return []time.Duration{math.MaxInt64, math.MinInt64}[rng.Intn(2)]
+		default:
+			return time.Duration(rng.Int63() - rng.Int63())
+		}
+	}
+
+	random := func() *Timespan {
+		return &Timespan{field(), field(), field(), field(), duration()}
+	}
+
+	spans := []*Timespan{nil, {}}
+	for i := 0; i < 1000; i++ {
+		ts := random()
+		spans = append(spans, ts)
+
+		// Include equal copies so both directions are exercised.
+		if rng.Intn(4) == 0 {
+			c := *ts
+			spans = append(spans, &c)
+		}
+	}
+
+	canon := make([]string, len(spans))
+	for i, ts := range spans {
+		canon[i] = ts.Canonical()
+
+		if ts != nil {
+			if got, err := ParseTimespan(canon[i]); err != nil || !got.Equal(ts) {
+				t.Errorf("ParseTimespan((%#v).Canonical() = %q) == (%#v, %v); Wanted %#v", ts, canon[i], got, err, ts)
+			}
+		}
+	}
+
+	for i, a := range spans {
+		for j := i; j < len(spans); j++ {
+			if eq, same := a.Equal(spans[j]), canon[i] == canon[j]; eq != same {
+				t.Errorf("(%#v).Equal(%#v) == %v but Canonical forms are %q and %q", a, spans[j], eq, canon[i], canon[j])
+			}
+		}
+	}
+}
+
+func TestTimespanGoString(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want string
+	}{
+		{nil, "(*timespan.Timespan)(nil)"},
+		{&Timespan{}, "timespan.New(0, 0, 0, 0)"},
+		{&Timespan{Years: 1, Months: 6, Days: 14}, `timespan.MustParseTimespan("1Y6M14D")`},
+		{&Timespan{Years: -1, Months: -6}, `timespan.MustParseTimespan("-1Y-6M")`},
+		{&Timespan{-1, 2, 0, -3, time.Hour}, `timespan.MustParseTimespan("-1Y+2M-3D1h")`},
+		{&Timespan{Weeks: 2, Duration: -time.Second}, `timespan.MustParseTimespan("2W-1s")`},
+		{&Timespan{Duration: 90 * time.Minute}, `timespan.MustParseTimespan("1h30m")`},
+	}
+
+	for _, tc := range cases {
+		got := fmt.Sprintf("%#v", tc.ts)
+		if got != tc.want {
+			t.Errorf("Sprintf(%q, %v) == %s; Wanted %s", "%#v", tc.ts, got, tc.want)
+			continue
+		}
+
+		if tc.ts == nil {
+			continue
+		}
+
+		// Evaluate the expression as the Go compiler would.
+		var rebuilt *Timespan
+		if tc.ts.IsZero() {
+			rebuilt = New(0, 0, 0, 0)
+		} else {
+			arg := strings.TrimSuffix(strings.TrimPrefix(got, "timespan.MustParseTimespan("), ")")
+			s, err := strconv.Unquote(arg)
+			if err != nil {
+				t.Errorf("%s: cannot unquote %s: %v", got, arg, err)
+				continue
+			}
+			rebuilt = MustParseTimespan(s)
+		}
+
+		if !rebuilt.Equal(tc.ts) {
+			t.Errorf("%s == %+v; Wanted %+v", got, rebuilt, tc.ts)
+		}
+	}
+}
+
+// foldedEqual reports whether a and b are equal once each one's weeks are
+// folded into its days, as happens when rendering with the Weeks option.
+func foldedEqual(a, b *Timespan) bool {
+	x, y := a.orZero(), b.orZero()
+	return x.Years == y.Years && x.Months == y.Months && x.days() == y.days() && x.Duration == y.Duration
+}
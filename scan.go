@@ -0,0 +1,54 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Scan implements the fmt.Scanner interface so that a *Timespan may be used
+// as an argument to fmt.Sscan, fmt.Fscanf and friends.
+//
+// After skipping any leading space, Scan consumes runes up to the first
+// space or any other rune that cannot be part of a Timespan string; these
+// are then parsed using ParseTimespan. Only the 'v' and 's' verbs are
+// supported.
+func (ts *Timespan) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' {
+		return fmt.Errorf("timespan: bad verb '%%%c' for Timespan", verb)
+	}
+
+	tok, err := state.Token(true, isTimespanRune)
+	if err != nil {
+		return err
+	}
+
+	if len(tok) == 0 {
+		return io.ErrUnexpectedEOF
+	}
+
+	return ParseTimespanInto(string(tok), ts)
+}
+
+// isTimespanRune returns true for each rune that may appear in a Timespan
+// string; i.e. signs, digits, magnitudes and the units (and decimal point)
+// accepted by time.ParseDuration.
+func isTimespanRune(r rune) bool {
+	return (r >= '0' && r <= '9') || strings.ContainsRune("+-.YMWDdhmsunµμ", r)
+}
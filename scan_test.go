@@ -0,0 +1,80 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestScan(t *testing.T) {
+	var ts Timespan
+	var rest string
+
+	n, err := fmt.Sscan("  1Y6M extra data", &ts, &rest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 2 {
+		t.Errorf("fmt.Sscan scanned %d items; Wanted 2", n)
+	}
+
+	if want := (&Timespan{Years: 1, Months: 6}); !ts.Equal(want) {
+		t.Errorf("Scanned Timespan mismatch: Got %+v; Wanted %+v", ts, want)
+	}
+
+	if rest != "extra" {
+		t.Errorf("Scan consumed too much input: next token is %q; Wanted %q", rest, "extra")
+	}
+}
+
+func TestScanf(t *testing.T) {
+	var ts Timespan
+	var name string
+
+	if _, err := fmt.Sscanf("ttl=-1W+2D1h30m; name=foo", "ttl=%v; name=%s", &ts, &name); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&Timespan{Weeks: -1, Days: 2, Duration: 90 * time.Minute}); !ts.Equal(want) {
+		t.Errorf("Scanned Timespan mismatch: Got %+v; Wanted %+v", ts, want)
+	}
+
+	if name != "foo" {
+		t.Errorf("Scanned name mismatch: Got %q; Wanted %q", name, "foo")
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	var ts Timespan
+
+	if _, err := fmt.Sscan("1D2W", &ts); err == nil {
+		t.Error("No error scanning invalid Timespan")
+	} else if pe, ok := err.(*ParseError); !ok || pe.Type() != MagnOutOfOrderErr {
+		t.Errorf("Unexpected error scanning invalid Timespan: %v", err)
+	}
+
+	if _, err := fmt.Sscan("", &ts); err == nil {
+		t.Error("No error scanning empty input")
+	}
+
+	if _, err := fmt.Sscanf("1D", "%d", &ts); err == nil {
+		t.Error("No error scanning Timespan with unsupported verb")
+	}
+}
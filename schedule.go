@@ -0,0 +1,124 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const scheduleSep = "@"
+
+// A Schedule is a Timespan anchored to a time of day, typically acquired
+// from ParseSchedule. Applying "3D@09:00" to a point in time yields 09:00 on
+// the third day after it, regardless of the time of day it is applied to.
+//
+// This differs from a Timespan such as "3D9h", whose Duration is added to
+// the time of day it's applied to; from 14:00, "3D9h" lands at 23:00 three
+// days later while "3D@09:00" still lands at 09:00.
+type Schedule struct {
+	Span   *Timespan
+	Hour   int
+	Minute int
+}
+
+// From returns the time.Time that results from applying s.Span to t (as with
+// Timespan.From) and then setting the time of day of the result to s.Hour
+// and s.Minute, with zero seconds, in t's Location. The time of day is set
+// absolutely rather than added, so any Duration in s.Span only matters if it
+// moves the result to a different date. If the time of day doesn't exist on
+// the resulting date, or occurs twice, because of a DST transition, the
+// result is as described for time.Date. A nil Span is treated as a zero span.
+func (s Schedule) From(t time.Time) time.Time {
+	y, m, d := s.Span.From(t).Date()
+	return time.Date(y, m, d, s.Hour, s.Minute, 0, 0, t.Location())
+}
+
+// String renders s in a form parseable by ParseSchedule.
+func (s Schedule) String() string {
+	span := ""
+	if !s.Span.IsZero() {
+		span = s.Span.String()
+	}
+
+	return fmt.Sprintf("%s%s%02d:%02d", span, scheduleSep, s.Hour, s.Minute)
+}
+
+// ParseSchedule parses a Timespan anchored to a time of day, expressed as a
+// Timespan string followed by "@" and a 24-hour clock time as "HH:MM", e.g.
+// "3D@09:00" or "1W@17:30". The Timespan is parsed using ParseTimespan and
+// may be omitted, as in "@09:00", to indicate a zero span (i.e. the same day).
+//
+// A string without the "@" separator results in a *ParseError of type
+// BadScheduleErr while a malformed or out of range time of day results in
+// one of type BadTimeOfDayErr. Any error is a *ParseError whose Input is s.
+func ParseSchedule(s string) (*Schedule, error) {
+	i := strings.Index(s, scheduleSep)
+	if i < 0 {
+		return nil, timespanError(BadScheduleErr, "missing %q separator", scheduleSep).withTimespan(s)
+	}
+
+	ss, cs := s[:i], s[i+len(scheduleSep):]
+
+	hour, min, ok := parseClock(cs)
+	if !ok {
+		return nil, timespanError(BadTimeOfDayErr, "invalid time of day: %q", cs).withTimespan(s)
+	}
+
+	sched := &Schedule{Span: &Timespan{}, Hour: hour, Minute: min}
+
+	if ss == "" {
+		return sched, nil
+	}
+
+	ts, err := ParseTimespan(ss)
+	if err != nil {
+		if pe, ok := err.(*ParseError); ok {
+			return nil, pe.withTimespan(s)
+		}
+		return nil, err
+	}
+
+	sched.Span = ts
+	return sched, nil
+}
+
+// parseClock parses a time of day given as "HH:MM" (or "H:MM") on a 24-hour
+// clock, returning false if it is malformed or out of range.
+func parseClock(s string) (hour, min int, ok bool) {
+	i := strings.Index(s, ":")
+	if i < 1 || i > 2 || len(s)-i-1 != 2 {
+		return 0, 0, false
+	}
+
+	for _, r := range s[:i] + s[i+1:] {
+		if r < '0' || r > '9' {
+			return 0, 0, false
+		}
+	}
+
+	hour, _ = strconv.Atoi(s[:i])
+	min, _ = strconv.Atoi(s[i+1:])
+
+	if hour > 23 || min > 59 {
+		return 0, 0, false
+	}
+
+	return hour, min, true
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := []struct {
+		str  string
+		want *Schedule
+	}{
+		{"3D@09:00", &Schedule{&Timespan{Days: 3}, 9, 0}},
+		{"+3D@9:00", &Schedule{&Timespan{Days: 3}, 9, 0}},
+		{"1W@17:30", &Schedule{&Timespan{Weeks: 1}, 17, 30}},
+		{"-1M@23:59", &Schedule{&Timespan{Months: -1}, 23, 59}},
+		{"@00:00", &Schedule{&Timespan{}, 0, 0}},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseSchedule(tc.str)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) returned error: %v", tc.str, err)
+			continue
+		}
+
+		if !got.Span.Equal(tc.want.Span) || got.Hour != tc.want.Hour || got.Minute != tc.want.Minute {
+			t.Errorf("ParseSchedule(%q) == %+v; Wanted %+v", tc.str, got, tc.want)
+		}
+	}
+}
+
+func TestParseScheduleBad(t *testing.T) {
+	data := []testdata{
+		{str: "3D", etype: BadScheduleErr},
+		{str: "", etype: BadScheduleErr},
+		{str: "3D@", etype: BadTimeOfDayErr},
+		{str: "3D@9", etype: BadTimeOfDayErr},
+		{str: "3D@24:00", etype: BadTimeOfDayErr},
+		{str: "3D@09:60", etype: BadTimeOfDayErr},
+		{str: "3D@09:5", etype: BadTimeOfDayErr},
+		{str: "3D@+9:00", etype: BadTimeOfDayErr},
+		{str: "3D@009:00", etype: BadTimeOfDayErr},
+		{str: "3D@09:00@10:00", etype: BadTimeOfDayErr},
+		{str: "1D2W@09:00", etype: MagnOutOfOrderErr},
+	}
+
+	for _, td := range data {
+		_, err := ParseSchedule(td.str)
+
+		if pe, ok := err.(*ParseError); !ok {
+			t.Errorf("ParseSchedule(%q) error == %v; Wanted a %v ParseError", td.str, err, td.etype)
+		} else if pe.Type() != td.etype || pe.Input() != td.str {
+			t.Errorf("Error mismatch parsing invalid schedule %q: got (%v, %q); wanted (%v, %q)", td.str, pe.Type(), pe.Input(), td.etype, td.str)
+		}
+	}
+}
+
+func TestScheduleFrom(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	at := time.Date(2019, 3, 1, 14, 25, 30, 0, ny)
+
+	cases := []struct {
+		sched string
+		want  time.Time
+	}{
+		{"3D@09:00", time.Date(2019, 3, 4, 9, 0, 0, 0, ny)},
+		{"@18:00", time.Date(2019, 3, 1, 18, 0, 0, 0, ny)},
+		{"@08:00", time.Date(2019, 3, 1, 8, 0, 0, 0, ny)},
+		{"12h@06:00", time.Date(2019, 3, 2, 6, 0, 0, 0, ny)},
+		{"-1M@12:00", time.Date(2019, 2, 1, 12, 0, 0, 0, ny)},
+		{"9D@12:00", time.Date(2019, 3, 10, 12, 0, 0, 0, ny)},
+	}
+
+	for _, tc := range cases {
+		s, err := ParseSchedule(tc.sched)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := s.From(at); !got.Equal(tc.want) {
+			t.Errorf("(%v).From(%v) == %v; Wanted %v", s, at, got, tc.want)
+		}
+	}
+
+	// Compare with a relative Duration, which is added to the time of day.
+	if got, want := (&Timespan{Days: 3, Duration: 9 * time.Hour}).From(at), time.Date(2019, 3, 4, 23, 25, 30, 0, ny); !got.Equal(want) {
+		t.Errorf("3D9h from %v == %v; Wanted %v", at, got, want)
+	}
+}
+
+func TestScheduleString(t *testing.T) {
+	for _, s := range []string{"3D@09:00", "1Y2M@23:59", "@07:05", "-1W-1D@00:00", "1D12h0m0s@12:00"} {
+		sched, err := ParseSchedule(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := sched.String(); got != s {
+			t.Errorf("ParseSchedule(%q).String() == %q", s, got)
+		}
+	}
+
+	if got, want := (Schedule{Hour: 9}).String(), "@09:00"; got != want {
+		t.Errorf("Schedule{Hour: 9}.String() == %q; Wanted %q", got, want)
+	}
+}
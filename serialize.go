@@ -0,0 +1,92 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// SerializedSize is the length in bytes of the encoding produced by
+// Serialize.
+const SerializedSize = 20
+
+// Serialize encodes ts into exactly SerializedSize bytes: Years, Months and
+// Days, each as a big-endian int32, followed by Duration as a big-endian
+// int64 count of nanoseconds. Weeks has no slot of its own and is folded into
+// Days as 7 days per week, so "1W2D" is decoded by Deserialize as "9D". Since
+// the encoding is fixed-width and big-endian, the bytes of positive,
+// normalized Timespans sort in the same order as the Timespans themselves (as
+// with Compare, once any Weeks are folded into Days), making them suitable as
+// keys in ordered key/value stores.
+//
+// An error is returned if Years, Months or the combined total of Weeks and
+// Days lies outside the range of an int32; use ToSlice for a wider numeric
+// form. A nil Timespan is encoded as a zero span.
+func (ts *Timespan) Serialize() ([]byte, error) {
+	v := ts.orZero()
+
+	days, ok := v.totalDays()
+	if !ok {
+		return nil, fmt.Errorf("timespan: weeks and days overflow int")
+	}
+
+	for _, m := range []struct {
+		name string
+		val  int
+	}{
+		{"years", v.Years},
+		{"months", v.Months},
+		{"days", days},
+	} {
+		if int64(m.val) < math.MinInt32 || int64(m.val) > math.MaxInt32 {
+			return nil, fmt.Errorf("timespan: %s %d out of range for serialization", m.name, m.val)
+		}
+	}
+
+	b := make([]byte, SerializedSize)
+
+	binary.BigEndian.PutUint32(b[0:], uint32(int32(v.Years)))
+	binary.BigEndian.PutUint32(b[4:], uint32(int32(v.Months)))
+	binary.BigEndian.PutUint32(b[8:], uint32(int32(days)))
+	binary.BigEndian.PutUint64(b[12:], uint64(v.Duration))
+
+	return b, nil
+}
+
+// Deserialize returns a pointer to a new Timespan decoded from b, which must
+// hold exactly SerializedSize bytes in the format produced by Serialize. The
+// result's Weeks member is always zero. If b is too short,
+// io.ErrUnexpectedEOF is returned.
+func Deserialize(b []byte) (*Timespan, error) {
+	switch {
+	case len(b) < SerializedSize:
+		return nil, io.ErrUnexpectedEOF
+	case len(b) > SerializedSize:
+		return nil, fmt.Errorf("timespan: serialized Timespan has %d bytes; wanted %d", len(b), SerializedSize)
+	}
+
+	return &Timespan{
+		Years:    int(int32(binary.BigEndian.Uint32(b[0:]))),
+		Months:   int(int32(binary.BigEndian.Uint32(b[4:]))),
+		Days:     int(int32(binary.BigEndian.Uint32(b[8:]))),
+		Duration: time.Duration(binary.BigEndian.Uint64(b[12:])),
+	}, nil
+}
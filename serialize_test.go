@@ -0,0 +1,157 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sort"
+	"testing"
+	"time"
+)
+
+// serialize is Serialize for spans known to be in range.
+func serialize(t *testing.T, ts *Timespan) []byte {
+	t.Helper()
+
+	b, err := ts.Serialize()
+	if err != nil {
+		t.Fatalf("(%v).Serialize() returned error: %v", ts, err)
+	}
+
+	return b
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	cases := []struct {
+		ts, want *Timespan
+	}{
+		{&Timespan{}, &Timespan{}},
+		{&Timespan{1, 2, 0, 4, 5 * time.Hour}, &Timespan{1, 2, 0, 4, 5 * time.Hour}},
+		{&Timespan{1, 2, 3, 4, 5 * time.Hour}, &Timespan{1, 2, 0, 25, 5 * time.Hour}},
+		{&Timespan{-1, 2, -3, 4, -time.Nanosecond}, &Timespan{-1, 2, 0, -17, -time.Nanosecond}},
+		{&Timespan{math.MaxInt32, math.MinInt32, 0, math.MaxInt32, math.MaxInt64}, &Timespan{math.MaxInt32, math.MinInt32, 0, math.MaxInt32, math.MaxInt64}},
+		{&Timespan{Duration: math.MinInt64}, &Timespan{Duration: math.MinInt64}},
+		{nil, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		b := serialize(t, tc.ts)
+		if len(b) != SerializedSize {
+			t.Errorf("len((%v).Serialize()) == %d; Wanted %d", tc.ts, len(b), SerializedSize)
+		}
+
+		got, err := Deserialize(b)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("Deserialize((%v).Serialize()) == (%v, %v); Wanted (%v, nil)", tc.ts, got, err, tc.want)
+		}
+	}
+}
+
+func TestSerializeInt32Range(t *testing.T) {
+	members := func(ts *Timespan) []*int {
+		return []*int{&ts.Years, &ts.Months, &ts.Days}
+	}
+
+	for i := range members(&Timespan{}) {
+		for _, n := range []int64{math.MinInt32, math.MinInt32 + 1, -1, 0, 1, math.MaxInt32 - 1, math.MaxInt32} {
+			var ts Timespan
+			*members(&ts)[i] = int(n)
+
+			if got, err := Deserialize(serialize(t, &ts)); err != nil || !got.Equal(&ts) {
+				t.Errorf("Deserialize((%v).Serialize()) == (%v, %v); Wanted (%v, nil)", &ts, got, err, &ts)
+			}
+		}
+
+		if maxInt == math.MaxInt32 {
+			continue
+		}
+
+		for _, n := range []int64{math.MaxInt32 + 1, math.MinInt32 - 1, 1 << 32} {
+			var ts Timespan
+			*members(&ts)[i] = int(n)
+
+			if b, err := ts.Serialize(); err == nil {
+				t.Errorf("(%v).Serialize() == %x; Wanted an error", &ts, b)
+			}
+		}
+	}
+
+	// Weeks are folded into Days so their total must fit as well.
+	for _, ts := range []*Timespan{
+		{Weeks: math.MaxInt32 / 7, Days: 7},
+		{Weeks: math.MinInt32 / 7, Days: -7},
+		{Weeks: maxInt},
+	} {
+		if b, err := ts.Serialize(); err == nil {
+			t.Errorf("(%v).Serialize() == %x; Wanted an error", ts, b)
+		}
+	}
+}
+
+func TestSerializeLayout(t *testing.T) {
+	got := serialize(t, &Timespan{1, -2, 3, 4, 5})
+	want := []byte{
+		0, 0, 0, 1,
+		0xff, 0xff, 0xff, 0xfe,
+		0, 0, 0, 25,
+		0, 0, 0, 0, 0, 0, 0, 5,
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("Serialize() == %x; Wanted %x", got, want)
+	}
+}
+
+func TestSerializeSortable(t *testing.T) {
+	spans := []*Timespan{
+		{Years: 1},
+		{Days: 3},
+		{Months: 13},
+		{Days: 8},
+		{Duration: 400 * time.Hour},
+		{Years: 1, Duration: time.Nanosecond},
+		{Months: 2, Days: 30},
+	}
+
+	keys := make([][]byte, len(spans))
+	for i, ts := range spans {
+		keys[i] = serialize(t, ts)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	SortTimespans(spans)
+
+	for i, ts := range spans {
+		if got, _ := Deserialize(keys[i]); !got.Equal(ts) {
+			t.Errorf("element %d in key order == %v; Wanted %v", i, got, ts)
+		}
+	}
+}
+
+func TestDeserializeBad(t *testing.T) {
+	for _, n := range []int{0, 1, SerializedSize - 1} {
+		if got, err := Deserialize(make([]byte, n)); err != io.ErrUnexpectedEOF || got != nil {
+			t.Errorf("Deserialize(%d bytes) == (%v, %v); Wanted (nil, %v)", n, got, err, io.ErrUnexpectedEOF)
+		}
+	}
+
+	if got, err := Deserialize(make([]byte, SerializedSize+1)); err == nil || got != nil {
+		t.Errorf("Deserialize(%d bytes) == (%v, %v); Wanted an error", SerializedSize+1, got, err)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface so that a Timespan is
+// logged using its canonical string form rather than as a struct. A zero
+// (or nil) Timespan is logged as an empty string.
+func (ts *Timespan) LogValue() slog.Value {
+	if ts.IsZero() {
+		return slog.StringValue("")
+	}
+
+	return slog.StringValue(ts.String())
+}
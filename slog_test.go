@@ -0,0 +1,68 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Ensure *Timespan implements slog.LogValuer
+var _ slog.LogValuer = (*Timespan)(nil)
+
+func TestLogValue(t *testing.T) {
+	cases := map[string]*lvTestcase{
+		"nil":   {nil, ""},
+		"zero":  {&Timespan{}, ""},
+		"span":  {&Timespan{Years: 1, Months: 6}, "1Y6M"},
+		"clock": {&Timespan{Days: 2, Duration: 90 * time.Minute}, "2D1h30m0s"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, tc.test)
+	}
+}
+
+type lvTestcase struct {
+	ts   *Timespan
+	want string
+}
+
+func (tc *lvTestcase) test(t *testing.T) {
+	v := tc.ts.LogValue()
+
+	if v.Kind() != slog.KindString || v.String() != tc.want {
+		t.Errorf("(%v).LogValue() == %v(%q); Wanted %v(%q)", tc.ts, v.Kind(), v.String(), slog.KindString, tc.want)
+	}
+}
+
+func TestLogValueHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	logger.Info("cache", slog.Any("ttl", &Timespan{Months: 18}))
+
+	if got := buf.String(); !strings.Contains(got, "ttl=18M") {
+		t.Errorf("log output %q lacks %q", got, "ttl=18M")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"sort"
+	"time"
+)
+
+// ByAbsoluteAt implements sort.Interface to order Spans by the point in time
+// each resolves to when applied to At (as with CompareAt), from earliest to
+// latest. Nil elements are ordered before all others.
+type ByAbsoluteAt struct {
+	Spans []*Timespan
+	At    time.Time
+}
+
+// Len is part of sort.Interface
+func (b ByAbsoluteAt) Len() int { return len(b.Spans) }
+
+// Swap is part of sort.Interface
+func (b ByAbsoluteAt) Swap(i, j int) { b.Spans[i], b.Spans[j] = b.Spans[j], b.Spans[i] }
+
+// Less is part of sort.Interface
+func (b ByAbsoluteAt) Less(i, j int) bool { return b.less(i, j, 1) }
+
+// less reports whether element i is ordered before element j, with nil
+// elements always first and all others ordered according to dir.
+func (b ByAbsoluteAt) less(i, j, dir int) bool {
+	si, sj := b.Spans[i], b.Spans[j]
+
+	switch {
+	case si == nil:
+		return sj != nil
+	case sj == nil:
+		return false
+	default:
+		return si.CompareAt(sj, b.At) == -dir
+	}
+}
+
+// byAbsoluteAtDesc is like ByAbsoluteAt except that non-nil elements are
+// ordered from latest to earliest.
+type byAbsoluteAtDesc struct{ ByAbsoluteAt }
+
+func (b byAbsoluteAtDesc) Less(i, j int) bool { return b.less(i, j, -1) }
+
+// SortTimespansAt sorts spans in place, in ascending order, as evaluated at
+// Time t. The sort is stable so spans that are equivalent at t (such as "1M"
+// and "28D" in February) retain their relative order. Nil elements are
+// sorted to the front.
+func SortTimespansAt(spans []*Timespan, t time.Time) {
+	sort.Stable(ByAbsoluteAt{spans, t})
+}
+
+// SortTimespansAtDescending is like SortTimespansAt except that spans are
+// sorted in descending order. Nil elements are still sorted to the front and
+// equivalent spans still retain their relative order.
+func SortTimespansAtDescending(spans []*Timespan, t time.Time) {
+	sort.Stable(byAbsoluteAtDesc{ByAbsoluteAt{spans, t}})
+}
+
+// byCompare implements sort.Interface to order spans with Compare, with nil
+// elements ordered before all others.
+type byCompare []*Timespan
+
+func (b byCompare) Len() int      { return len(b) }
+func (b byCompare) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+func (b byCompare) Less(i, j int) bool {
+	switch {
+	case b[i] == nil:
+		return b[j] != nil
+	case b[j] == nil:
+		return false
+	default:
+		return b[i].Compare(b[j]) < 0
+	}
+}
+
+// SortTimespans sorts spans in place, in ascending order according to
+// Compare. Since no reference time is involved, the order is structural
+// rather than by length; use SortTimespansAt to order spans by the point in
+// time each resolves to. Nil elements are sorted to the front.
+func SortTimespans(spans []*Timespan) {
+	sort.Stable(byCompare(spans))
+}
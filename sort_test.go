@@ -0,0 +1,102 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// Ensure ByAbsoluteAt implements sort.Interface
+var _ sort.Interface = ByAbsoluteAt{}
+
+func TestSortTimespansAt(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	day := &Timespan{Days: 1}
+	hours := &Timespan{Duration: 24 * time.Hour}
+	month := &Timespan{Months: 1}
+	days28 := &Timespan{Days: 28}
+	week := &Timespan{Days: 7}
+	back := &Timespan{Days: -3}
+
+	spans := []*Timespan{month, week, nil, hours, back, days28, day, nil}
+
+	asc := append([]*Timespan(nil), spans...)
+	SortTimespansAt(asc, at)
+	checkOrder(t, "SortTimespansAt", asc, []*Timespan{nil, nil, back, hours, day, week, month, days28})
+
+	desc := append([]*Timespan(nil), spans...)
+	SortTimespansAtDescending(desc, at)
+	checkOrder(t, "SortTimespansAtDescending", desc, []*Timespan{nil, nil, month, days28, week, hours, day, back})
+
+	// In March, a month is longer than 28 days.
+	mar := append([]*Timespan(nil), spans...)
+	sort.Sort(ByAbsoluteAt{mar, at.AddDate(0, 1, 0)})
+	checkOrder(t, "sort.Sort", mar[6:], []*Timespan{days28, month})
+}
+
+func TestSortTimespans(t *testing.T) {
+	year := &Timespan{Years: 1}
+	months := &Timespan{Months: 13}
+	week := &Timespan{Weeks: 1}
+	days := &Timespan{Days: 7}
+	hours := &Timespan{Duration: 200 * time.Hour}
+	back := &Timespan{Months: -1, Days: 40}
+
+	spans := []*Timespan{year, nil, hours, days, back, week, months}
+	SortTimespans(spans)
+	checkOrder(t, "SortTimespans", spans, []*Timespan{nil, back, hours, days, week, months, year})
+}
+
+func TestTimespanCompare(t *testing.T) {
+	cases := []struct {
+		ts, ots *Timespan
+		want    int
+	}{
+		{nil, nil, 0},
+		{nil, &Timespan{}, 0},
+		{&Timespan{1, 2, 3, 4, 5}, &Timespan{1, 2, 3, 4, 5}, 0},
+		{&Timespan{Years: 1}, &Timespan{Months: 13}, 1},
+		{&Timespan{Months: 1}, &Timespan{Days: 40}, 1},
+		{&Timespan{Weeks: 1}, &Timespan{Days: 7}, 1},
+		{&Timespan{Days: 1}, &Timespan{Duration: 48 * time.Hour}, 1},
+		{&Timespan{Duration: -1}, nil, -1},
+		{&Timespan{Years: -1, Months: 20}, &Timespan{Months: 1}, -1},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.Compare(tc.ots); got != tc.want {
+			t.Errorf("(%v).Compare(%v) == %d; Wanted %d", tc.ts, tc.ots, got, tc.want)
+		}
+
+		if got := tc.ots.Compare(tc.ts); got != -tc.want {
+			t.Errorf("(%v).Compare(%v) == %d; Wanted %d", tc.ots, tc.ts, got, -tc.want)
+		}
+	}
+}
+
+func checkOrder(t *testing.T, name string, got, want []*Timespan) {
+	t.Helper()
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s: element %d == %v; Wanted %v (full result: %v)", name, i, got[i], want[i], got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// NullTimespan represents a Timespan that may be null. Analogous to
+// sql.NullString, NullTimespan implements the sql.Scanner and driver.Valuer
+// interfaces so it can be used with nullable database columns holding a
+// Timespan in its string form.
+type NullTimespan struct {
+	Span  Timespan
+	Valid bool // Valid is true if Span is not NULL
+}
+
+// Scan implements the sql.Scanner interface. A NULL value results in a zero
+// Span with Valid set to false. Otherwise, value must be a string (or
+// []byte) that is parsed using ParseTimespan. Since the zero Timespan renders
+// as an empty string, an empty (but non-NULL) value is scanned as a valid,
+// zero Span.
+func (nt *NullTimespan) Scan(value interface{}) error {
+	var s string
+
+	switch v := value.(type) {
+	case nil:
+		nt.Span, nt.Valid = Timespan{}, false
+		return nil
+
+	case string:
+		s = v
+
+	case []byte:
+		s = string(v)
+
+	default:
+		return fmt.Errorf("timespan: cannot scan %T into NullTimespan", value)
+	}
+
+	var ts Timespan
+	if s != "" {
+		if err := ParseTimespanInto(s, &ts); err != nil {
+			return err
+		}
+	}
+
+	nt.Span, nt.Valid = ts, true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns nil if nt is not
+// Valid and the string form of its Span otherwise.
+func (nt NullTimespan) Value() (driver.Value, error) {
+	if !nt.Valid {
+		return nil, nil
+	}
+
+	return nt.Span.String(), nil
+}
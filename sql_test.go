@@ -0,0 +1,88 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+// Ensure NullTimespan implements sql.Scanner and driver.Valuer
+var (
+	_ sql.Scanner   = (*NullTimespan)(nil)
+	_ driver.Valuer = NullTimespan{}
+)
+
+func TestNullTimespanScan(t *testing.T) {
+	cases := []struct {
+		value interface{}
+		want  NullTimespan
+	}{
+		{nil, NullTimespan{}},
+		{"1Y6M", NullTimespan{Timespan{Years: 1, Months: 6}, true}},
+		{[]byte("2D3h"), NullTimespan{Timespan{Days: 2, Duration: 3 * time.Hour}, true}},
+		{"", NullTimespan{Timespan{}, true}},
+	}
+
+	for _, tc := range cases {
+		// Start from a populated value to ensure Scan overwrites it.
+		got := NullTimespan{Timespan{1, 2, 0, 3, 4}, true}
+
+		if err := got.Scan(tc.value); err != nil {
+			t.Errorf("Scan(%#v) returned error: %v", tc.value, err)
+			continue
+		}
+
+		if got.Valid != tc.want.Valid || !got.Span.Equal(&tc.want.Span) {
+			t.Errorf("Scan(%#v) == %+v; Wanted %+v", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestNullTimespanScanErrors(t *testing.T) {
+	for _, v := range []interface{}{"1D2W", 42, 3.14} {
+		var nt NullTimespan
+		if err := nt.Scan(v); err == nil {
+			t.Errorf("No error scanning %#v into NullTimespan", v)
+		}
+	}
+}
+
+func TestNullTimespanValue(t *testing.T) {
+	cases := []struct {
+		nt   NullTimespan
+		want driver.Value
+	}{
+		{NullTimespan{}, nil},
+		{NullTimespan{Timespan{Years: 1}, false}, nil},
+		{NullTimespan{Timespan{Years: 1, Months: 6}, true}, "1Y6M"},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.nt.Value()
+		if err != nil {
+			t.Errorf("(%+v).Value() returned error: %v", tc.nt, err)
+			continue
+		}
+
+		if got != tc.want {
+			t.Errorf("(%+v).Value() == %#v; Wanted %#v", tc.nt, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"text/template"
+	"time"
+)
+
+// FuncMap returns a set of template functions for working with Timespans:
+//
+//	parseTimespan STRING           -> *Timespan (see ParseTimespan)
+//	formatTimespan TIMESPAN        -> compact string, e.g. "1Y2M3D4h"
+//	humanize TIMESPAN              -> verbose string, e.g. "1yr 2mo 3d 4h"
+//	addTimespan TIME TIMESPAN      -> TIMESPAN applied forward from TIME (From)
+//	subTimespan TIME TIMESPAN      -> TIMESPAN applied backward from TIME (Before)
+//	compareTimespans A B TIME      -> -1, 0 or +1 (see CompareAt)
+//
+// A parse failure is returned from parseTimespan as an error, which aborts
+// template execution; none of the functions panic.
+//
+// Since templates readily pass nil (e.g. for an unset field), each function
+// treats a nil Timespan as a zero span. In particular, both formatTimespan
+// and humanize render nil, like any other zero span, as an empty string.
+//
+// For use with html/template, convert the result:
+//
+//	htmltemplate.FuncMap(timespan.FuncMap())
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"parseTimespan": ParseTimespan,
+
+		"formatTimespan": func(ts *Timespan) string {
+			return renderZero(ts, RenderOptions{TrimZeroUnits: true})
+		},
+
+		"humanize": func(ts *Timespan) string {
+			return renderZero(ts, RenderOptions{TrimZeroUnits: true, Verbose: true})
+		},
+
+		"addTimespan": func(t time.Time, ts *Timespan) time.Time {
+			return ts.From(t)
+		},
+
+		"subTimespan": func(t time.Time, ts *Timespan) time.Time {
+			return ts.Before(t)
+		},
+
+		"compareTimespans": func(ts, ots *Timespan, t time.Time) int {
+			return ts.CompareAt(ots, t)
+		},
+	}
+}
+
+// renderZero is StringWith except that a nil Timespan is rendered as a zero
+// span.
+func renderZero(ts *Timespan, opts RenderOptions) string {
+	v := ts.orZero()
+	return v.StringWith(opts)
+}
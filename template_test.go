@@ -0,0 +1,106 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+type report struct {
+	Window string
+	Start  time.Time
+	Span   *Timespan
+	Unset  *Timespan
+}
+
+const reportTmpl = `{{ $w := parseTimespan .Window -}}
+window:  {{ formatTimespan $w }}
+span:    {{ .Span | humanize }}
+unset:   [{{ humanize .Unset }}]
+end:     {{ (addTimespan .Start .Span).Format "2006-01-02 15:04" }}
+before:  {{ (subTimespan .Start $w).Format "2006-01-02" }}
+longer:  {{ compareTimespans .Span $w .Start }}
+`
+
+func ExampleFuncMap() {
+	tmpl := template.Must(template.New("report").Funcs(FuncMap()).Parse(reportTmpl))
+
+	err := tmpl.Execute(os.Stdout, &report{
+		Window: "1M2W",
+		Start:  time.Date(2019, 3, 31, 9, 0, 0, 0, time.UTC),
+		Span:   &Timespan{Months: 1, Days: 15, Duration: 90 * time.Minute},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// window:  1M2W
+	// span:    1mo 15d 1h 30min
+	// unset:   []
+	// end:     2019-05-16 10:30
+	// before:  2019-02-14
+	// longer:  1
+}
+
+func TestFuncMapErrors(t *testing.T) {
+	tmpl := template.Must(template.New("bad").Funcs(FuncMap()).Parse(`{{ parseTimespan .Window }}`))
+
+	var sb strings.Builder
+	err := tmpl.Execute(&sb, &report{Window: "1D2W"})
+	if err == nil {
+		t.Fatalf("Execute with bad Timespan succeeded: %q", sb.String())
+	}
+
+	if !strings.Contains(err.Error(), "1D2W") {
+		t.Errorf("Execute error == %q; Wanted it to mention the input", err)
+	}
+}
+
+func TestFuncMapNil(t *testing.T) {
+	start := time.Date(2019, 3, 31, 9, 0, 0, 0, time.UTC)
+	tmpl := template.Must(template.New("nil").Funcs(FuncMap()).Parse(
+		`{{ formatTimespan .Unset }}|{{ addTimespan .Start .Unset }}|{{ compareTimespans .Unset .Span .Start }}`))
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, &report{Start: start, Span: &Timespan{}}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got, want := sb.String(), "|"+start.String()+"|0"; got != want {
+		t.Errorf("Execute with nil Timespan == %q; Wanted %q", got, want)
+	}
+}
+
+func TestFuncMapHTML(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap(FuncMap())).Parse(
+		`<td>{{ .Span | humanize }}</td>`))
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, &report{Span: &Timespan{Years: -1, Months: 2}}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if got, want := sb.String(), "<td>-1yr &#43;2mo</td>"; got != want {
+		t.Errorf("Execute == %q; Wanted %q", got, want)
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A Clock is a source of the current time and of timers. It allows a Ticker
+// (or AgeClock) to be driven by something other than the system clock (e.g.
+// in tests).
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// A Timer is a single event produced by a Clock. It is modeled after
+// time.Timer except that its channel is returned by a method.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                 { return time.Now() }
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+
+type systemTimer struct{ t *time.Timer }
+
+func (st systemTimer) C() <-chan time.Time { return st.t.C }
+func (st systemTimer) Stop() bool          { return st.t.Stop() }
+
+// A Ticker holds a channel that delivers ticks on a calendar-aware schedule.
+// The first tick is scheduled at the start time given to NewTicker and each
+// later tick is scheduled by applying the Ticker's Timespan to the previous
+// scheduled time (not to the time at which it was delivered), so a monthly
+// Ticker does not drift even though months differ in length.
+//
+// Each value sent on C is the scheduled time of the tick. As with time.Ticker,
+// C has a buffer of one; if the receiver falls behind, missed ticks are
+// dropped rather than queued. If the Ticker's Timespan ever fails to move
+// the schedule forward, no further ticks are delivered.
+type Ticker struct {
+	C <-chan time.Time
+
+	c     chan time.Time
+	clock Clock
+	reset chan struct{}
+	stop  chan struct{}
+	once  sync.Once
+
+	mu     sync.Mutex
+	ts     *Timespan
+	next   time.Time
+	prev   time.Time
+	ticked bool
+}
+
+// NewTicker returns a new Ticker, driven by the system clock, whose ticks are
+// scheduled at start and then every ts thereafter. See NewTickerClock.
+func NewTicker(start time.Time, ts *Timespan) *Ticker {
+	return NewTickerClock(start, ts, SystemClock)
+}
+
+// NewTickerClock is like NewTicker except that the Ticker is driven by the
+// given Clock. If start is already in the past, the first tick is delivered
+// immediately.
+//
+// NewTickerClock panics if ts does not resolve to a positive span at start.
+// Stop the Ticker to release its associated resources.
+func NewTickerClock(start time.Time, ts *Timespan, clock Clock) *Ticker {
+	mustAdvance(ts, start)
+
+	c := make(chan time.Time, 1)
+	tk := &Ticker{
+		C:     c,
+		c:     c,
+		clock: clock,
+		reset: make(chan struct{}, 1),
+		stop:  make(chan struct{}),
+		ts:    ts,
+		next:  start,
+	}
+
+	go tk.run()
+
+	return tk
+}
+
+// Stop turns off the Ticker; no more ticks will be sent. Stop does not close
+// C. It is safe to call Stop more than once.
+func (tk *Ticker) Stop() {
+	tk.once.Do(func() { close(tk.stop) })
+}
+
+// Reset changes the Ticker's Timespan to ts. The next tick is rescheduled by
+// applying ts to the most recently scheduled tick or, if no tick has been
+// scheduled yet, remains at the start time. Reset has no effect on a stopped
+// Ticker.
+//
+// Reset panics if ts does not resolve to a positive span at that point.
+func (tk *Ticker) Reset(ts *Timespan) {
+	tk.mu.Lock()
+	if tk.ticked {
+		tk.next = mustAdvance(ts, tk.prev)
+	} else {
+		mustAdvance(ts, tk.next)
+	}
+	tk.ts = ts
+	tk.mu.Unlock()
+
+	select {
+	case tk.reset <- struct{}{}:
+	default:
+	}
+}
+
+func (tk *Ticker) run() {
+	for {
+		tk.mu.Lock()
+		next := tk.next
+		tk.mu.Unlock()
+
+		timer := tk.clock.NewTimer(next.Sub(tk.clock.Now()))
+
+		select {
+		case <-timer.C():
+		case <-tk.reset:
+			timer.Stop()
+			continue
+		case <-tk.stop:
+			timer.Stop()
+			return
+		}
+
+		select {
+		case tk.c <- next:
+		default:
+		}
+
+		if !tk.advance(next) {
+			return
+		}
+	}
+}
+
+// advance schedules the tick following sched, skipping any occurrences that
+// have already passed. It returns false if the schedule can't move forward.
+func (tk *Ticker) advance(sched time.Time) bool {
+	tk.mu.Lock()
+	defer tk.mu.Unlock()
+
+	if !tk.next.Equal(sched) {
+		// Reset while the tick was being delivered
+		return true
+	}
+
+	now := tk.clock.Now()
+
+	for {
+		n := tk.ts.From(sched)
+		if !n.After(sched) {
+			return false
+		}
+
+		tk.prev, tk.ticked = sched, true
+		sched = n
+
+		if sched.After(now) {
+			break
+		}
+	}
+
+	tk.next = sched
+
+	return true
+}
+
+// mustAdvance returns ts applied to t or panics if the result is not after t.
+func mustAdvance(ts *Timespan, t time.Time) time.Time {
+	n := ts.From(t)
+	if !n.After(t) {
+		panic(fmt.Sprintf("timespan: non-positive Ticker interval %v at %v", ts, t))
+	}
+	return n
+}
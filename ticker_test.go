@@ -0,0 +1,275 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only changes when set is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	added   chan time.Duration
+	stopped chan struct{}
+}
+
+type fakeTimer struct {
+	clk  *fakeClock
+	c    chan time.Time
+	when time.Time
+	done bool
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:     now,
+		added:   make(chan time.Duration, 16),
+		stopped: make(chan struct{}, 16),
+	}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) NewTimer(d time.Duration) Timer {
+	fc.mu.Lock()
+	ft := &fakeTimer{clk: fc, c: make(chan time.Time, 1), when: fc.now.Add(d)}
+	fc.timers = append(fc.timers, ft)
+	fc.fire()
+	fc.mu.Unlock()
+
+	fc.added <- d
+
+	return ft
+}
+
+// set moves the clock to t, firing any timers that have come due.
+func (fc *fakeClock) set(t time.Time) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fc.now = t
+	fc.fire()
+}
+
+func (fc *fakeClock) fire() {
+	for _, ft := range fc.timers {
+		if !ft.done && !ft.when.After(fc.now) {
+			ft.done = true
+			ft.c <- fc.now
+		}
+	}
+}
+
+// waitTimer waits for a new timer to be requested and returns its duration.
+func (fc *fakeClock) waitTimer(t *testing.T) time.Duration {
+	t.Helper()
+
+	select {
+	case d := <-fc.added:
+		return d
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a new timer")
+		return 0
+	}
+}
+
+// waitStopped waits for a timer to be stopped.
+func (fc *fakeClock) waitStopped(t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-fc.stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a timer to stop")
+	}
+}
+
+func (ft *fakeTimer) C() <-chan time.Time {
+	return ft.c
+}
+
+func (ft *fakeTimer) Stop() bool {
+	ft.clk.mu.Lock()
+	active := !ft.done
+	ft.done = true
+	ft.clk.mu.Unlock()
+
+	ft.clk.stopped <- struct{}{}
+
+	return active
+}
+
+func recvTick(t *testing.T, tk *Ticker) time.Time {
+	t.Helper()
+
+	select {
+	case tick := <-tk.C:
+		return tick
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a tick")
+		return time.Time{}
+	}
+}
+
+func TestTickerMonthly(t *testing.T) {
+	clk := newFakeClock(date(2019, 1, 1, 12))
+	tk := NewTickerClock(date(2019, 1, 15, 12), &Timespan{Months: 1}, clk)
+	defer tk.Stop()
+
+	// Each timer fires a minute late, but the schedule must not drift; the
+	// March tick is only 28 days after the February one.
+	for _, want := range []time.Time{date(2019, 1, 15, 12), date(2019, 2, 15, 12), date(2019, 3, 15, 12), date(2019, 4, 15, 12)} {
+		if got := clk.Now().Add(clk.waitTimer(t)); !got.Equal(want) {
+			t.Errorf("Ticker scheduled a timer for %v; Wanted %v", got, want)
+		}
+
+		clk.set(want.Add(time.Minute))
+
+		if got := recvTick(t, tk); !got.Equal(want) {
+			t.Errorf("Ticker sent %v; Wanted %v", got, want)
+		}
+	}
+}
+
+func TestTickerCoalesces(t *testing.T) {
+	day := func(d, h int) time.Time {
+		return time.Date(2019, 1, d, h, 0, 0, 0, time.UTC)
+	}
+
+	clk := newFakeClock(day(1, 0))
+	tk := NewTickerClock(day(1, 1), &Timespan{Days: 1}, clk)
+	defer tk.Stop()
+
+	clk.waitTimer(t)
+	clk.set(day(4, 13)) // miss the ticks on the 2nd, 3rd and 4th
+
+	if got, want := clk.waitTimer(t), 12*time.Hour; got != want {
+		t.Errorf("Ticker waited %v after falling behind; Wanted %v", got, want)
+	}
+
+	if got, want := recvTick(t, tk), day(1, 1); !got.Equal(want) {
+		t.Errorf("Ticker sent %v; Wanted %v", got, want)
+	}
+
+	select {
+	case got := <-tk.C:
+		t.Errorf("Ticker queued missed tick %v", got)
+	default:
+	}
+
+	clk.set(day(5, 1))
+
+	if got, want := recvTick(t, tk), day(5, 1); !got.Equal(want) {
+		t.Errorf("Ticker sent %v; Wanted %v", got, want)
+	}
+}
+
+func TestTickerReset(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clk := newFakeClock(start)
+	tk := NewTickerClock(start, &Timespan{Days: 1}, clk)
+	defer tk.Stop()
+
+	clk.waitTimer(t)
+	recvTick(t, tk)
+
+	if got, want := clk.waitTimer(t), 24*time.Hour; got != want {
+		t.Errorf("Ticker waited %v; Wanted %v", got, want)
+	}
+
+	tk.Reset(&Timespan{Days: 7})
+	clk.waitStopped(t)
+
+	if got, want := clk.waitTimer(t), 7*24*time.Hour; got != want {
+		t.Errorf("Ticker waited %v after Reset; Wanted %v", got, want)
+	}
+
+	clk.set(start.AddDate(0, 0, 7))
+
+	if got, want := recvTick(t, tk), start.AddDate(0, 0, 7); !got.Equal(want) {
+		t.Errorf("Ticker sent %v after Reset; Wanted %v", got, want)
+	}
+}
+
+func TestTickerStop(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clk := newFakeClock(start.Add(-time.Hour))
+	tk := NewTickerClock(start, &Timespan{Days: 1}, clk)
+
+	clk.waitTimer(t)
+	tk.Stop()
+	tk.Stop()
+	clk.waitStopped(t)
+
+	clk.set(start.AddDate(1, 0, 0))
+
+	select {
+	case got := <-tk.C:
+		t.Errorf("stopped Ticker sent %v", got)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestTickerNonAdvancing(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("new", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewTickerClock failed to panic on a zero span")
+			}
+		}()
+
+		NewTickerClock(start, &Timespan{}, newFakeClock(start))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		tk := NewTickerClock(start, &Timespan{Days: 1}, newFakeClock(start))
+		defer tk.Stop()
+
+		defer func() {
+			if recover() == nil {
+				t.Error("Reset failed to panic on a negative span")
+			}
+		}()
+
+		tk.Reset(&Timespan{Days: -1})
+	})
+}
+
+func TestTickerSystemClock(t *testing.T) {
+	ts := &Timespan{Duration: 10 * time.Millisecond}
+	tk := NewTicker(time.Now(), ts)
+	defer tk.Stop()
+
+	first := recvTick(t, tk)
+	second := recvTick(t, tk)
+
+	if got := second.Sub(first); got != ts.Duration {
+		t.Errorf("system Ticker interval == %v; Wanted %v", got, ts.Duration)
+	}
+}
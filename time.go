@@ -0,0 +1,109 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import "time"
+
+// FromTime converts t to a Time.
+func FromTime(t time.Time) Time {
+	return Time(t)
+}
+
+// ToTime converts t back to a time.Time.
+func (t Time) ToTime() time.Time {
+	return time.Time(t)
+}
+
+// Before reports whether t is before u; see time.Time.Before.
+func (t Time) Before(u Time) bool {
+	return time.Time(t).Before(time.Time(u))
+}
+
+// After reports whether t is after u; see time.Time.After.
+func (t Time) After(u Time) bool {
+	return time.Time(t).After(time.Time(u))
+}
+
+// Equal reports whether t and u represent the same instant; see
+// time.Time.Equal.
+func (t Time) Equal(u Time) bool {
+	return time.Time(t).Equal(time.Time(u))
+}
+
+// IsZero reports whether t is the zero time instant; see time.Time.IsZero.
+func (t Time) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// Format returns t formatted according to layout; see time.Time.Format.
+func (t Time) Format(layout string) string {
+	return time.Time(t).Format(layout)
+}
+
+// Unix returns t as a Unix time; see time.Time.Unix.
+func (t Time) Unix() int64 {
+	return time.Time(t).Unix()
+}
+
+// In returns a copy of t set to the Location loc; see time.Time.In.
+func (t Time) In(loc *time.Location) Time {
+	return Time(time.Time(t).In(loc))
+}
+
+// UTC returns a copy of t set to UTC; see time.Time.UTC.
+func (t Time) UTC() Time {
+	return Time(time.Time(t).UTC())
+}
+
+// Local returns a copy of t set to the local time zone; see time.Time.Local.
+func (t Time) Local() Time {
+	return Time(time.Time(t).Local())
+}
+
+// MarshalJSON implements the json.Marshaler interface using the same RFC 3339
+// representation as time.Time.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return time.Time(t).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and accepts
+// anything accepted by time.Time's UnmarshalJSON.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	return (*time.Time)(t).UnmarshalJSON(data)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface using the same
+// RFC 3339 representation as time.Time.
+func (t Time) MarshalText() ([]byte, error) {
+	return time.Time(t).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface and accepts
+// anything accepted by time.Time's UnmarshalText.
+func (t *Time) UnmarshalText(data []byte) error {
+	return (*time.Time)(t).UnmarshalText(data)
+}
+
+// Since returns the Timespan elapsed since t; see the Since function.
+func (t Time) Since() *Timespan {
+	return Since(time.Time(t))
+}
+
+// Until returns the Timespan remaining until t; see the Until function.
+func (t Time) Until() *Timespan {
+	return Until(time.Time(t))
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeJSONRoundTrip(t *testing.T) {
+	type event struct {
+		At Time `json:"at"`
+	}
+
+	want := FromTime(time.Date(2019, 3, 10, 6, 30, 15, 500, time.FixedZone("X", -5*3600)))
+
+	data, err := json.Marshal(event{want})
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	if std, _ := json.Marshal(want.ToTime()); string(data) != `{"at":`+string(std)+`}` {
+		t.Errorf("json.Marshal == %s; Wanted time.Time encoding %s", data, std)
+	}
+
+	var got event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+
+	if !got.At.Equal(want) {
+		t.Errorf("JSON round-trip == %v; Wanted %v", got.At, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"at":"bogus"}`), &got); err == nil {
+		t.Error("json.Unmarshal(bogus) returned no error")
+	}
+}
+
+func TestTimeTextRoundTrip(t *testing.T) {
+	want := FromTime(time.Date(2020, 2, 29, 23, 59, 59, 0, time.UTC))
+
+	text, err := want.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+
+	var got Time
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText(%q): %v", text, err)
+	}
+
+	if !got.Equal(want) {
+		t.Errorf("text round-trip == %v; Wanted %v", got, want)
+	}
+}
+
+func TestTimeParity(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	std := time.Date(2019, 11, 3, 1, 30, 0, 0, ny)
+	tt := FromTime(std)
+
+	for _, layout := range []string{time.RFC3339Nano, time.Kitchen, time.RFC1123Z, "2006-01-02 MST"} {
+		if got, want := tt.Format(layout), std.Format(layout); got != want {
+			t.Errorf("Format(%q) == %q; Wanted %q", layout, got, want)
+		}
+	}
+
+	if got, want := tt.Unix(), std.Unix(); got != want {
+		t.Errorf("Unix() == %d; Wanted %d", got, want)
+	}
+
+	if got, want := tt.UTC().ToTime(), std.UTC(); got != want {
+		t.Errorf("UTC() == %v; Wanted %v", got, want)
+	}
+
+	if got, want := tt.Local().ToTime(), std.Local(); got != want {
+		t.Errorf("Local() == %v; Wanted %v", got, want)
+	}
+
+	if got, want := tt.UTC().In(ny).ToTime(), std; !got.Equal(want) || got.Location() != ny {
+		t.Errorf("In(%v) == %v; Wanted %v", ny, got, want)
+	}
+
+	later := tt.Add(&Timespan{Duration: time.Second})
+
+	if !tt.Before(later) || tt.After(later) || !later.After(tt) || later.Before(tt) {
+		t.Errorf("Before/After disagree for %v and %v", tt, later)
+	}
+
+	if tt.Equal(later) || !tt.Equal(tt.UTC()) {
+		t.Errorf("Equal disagrees with time.Time.Equal for %v", tt)
+	}
+
+	if tt.IsZero() || !(Time{}).IsZero() {
+		t.Error("IsZero disagrees with time.Time.IsZero")
+	}
+}
+
+func TestTimeSinceUntil(t *testing.T) {
+	past := FromTime(time.Now().AddDate(0, -3, 0))
+	future := FromTime(time.Now().AddDate(0, 0, 10))
+
+	if got := past.Since(); got.Years != 0 || got.Months < 2 || got.Months > 3 {
+		t.Errorf("(%v).Since() == %v; Wanted about 3 months", past, got)
+	}
+
+	if got := future.Until(); got.Years != 0 || got.Months != 0 || got.Days < 9 || got.Days > 10 {
+		t.Errorf("(%v).Until() == %v; Wanted about 10 days", future, got)
+	}
+}
@@ -59,6 +59,31 @@ limitations under the License.
 // In most cases however, these ambiguities are understood at the human level
 // and Timespan will behave as the user intends without much further thought.
 //
+// Resolving a Timespan
+//
+// The concrete duration a Timespan represents at a given moment is provided
+// by AbsoluteAt; e.g. "1M" is 28 days (672 hours) at February 1, 2019 but 31
+// days at January 1. The other methods that evaluate a Timespan at a
+// reference time, such as EqualAt, CompareAt and RatioAt, are all defined in
+// terms of AbsoluteAt. When no reference time is available,
+// ApproximateDuration provides a rough estimate instead.
+//
+// Forward and Backward
+//
+// A Timespan may be applied forward from a point in time (using From) or
+// backward (using Before). Because months vary in length, these are not
+// mirror images of one another.
+//
+// From follows time.AddDate and normalizes any overflow past the end of a
+// month into the following month; e.g. Jan 31 + "1M" is Mar 3 (in a non-leap
+// year). Applying the negated span with From behaves the same way so Mar 31
+// + "-1M" would be "Feb 31", which is again normalized to Mar 3.
+//
+// Before instead clamps the day of the month to the last day of the target
+// month, yielding the more natural Mar 31 - "1M" = Feb 28. Consequently,
+// ts.Before(ts.From(t)) is not always t; Jan 31 + "1M" - "1M" is Feb 3.
+// FromClamped provides the same clamping behavior in the forward direction.
+//
 // Parsing
 //
 // A Timespan string is the conjunction of one or more periods (as
@@ -106,12 +131,17 @@ limitations under the License.
 // -1, Months: +2}), you must explicitly change the sign back to positive with
 // "-1Y+2M".
 //
-// Since a week is always 7 days, the available "W" magnitude is provided
-// merely as a convenience; it is not stored as part of the Timespan value.
-// Coefficients provided in weeks are stored as multiples of 7 days.
+// By default, the sticky sign does not extend into the trailing duration,
+// which is handed to time.ParseDuration as-is. The StickyDurationSign option
+// to ParseTimespanWith changes this so that, for example, "1h-30m" is parsed
+// as 30 minutes.
+//
+// Weeks are kept in their own Weeks field so that "4W1D" is rendered back as
+// "4W1D" (rather than "29D"), although each week is always applied as 7 days.
 //
 // If ParseTimespan is unable to parse the given string, it returns nil and an
-// appropriate error.
+// appropriate error; typically a *ParseError whose Type method classifies the
+// failure.
 //
 // Grammar
 //
@@ -143,8 +173,10 @@ package timespan // import "toolman.org/time/timespan/v2"
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // A Timespan represents a span of time with wide and varying resolutions.
@@ -156,6 +188,9 @@ type Timespan struct {
 	// Months in this Timespan
 	Months int
 
+	// Weeks in this Timespan; each is applied as 7 Days
+	Weeks int
+
 	// Days in this Timespan
 	Days int
 
@@ -168,43 +203,105 @@ type Timespan struct {
 //
 func ParseTimespan(s string) (*Timespan, error) {
 	ts := &Timespan{}
+	if err := ParseTimespanInto(s, ts); err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+// MustParseTimespan is like ParseTimespan but panics if s cannot be parsed.
+// It simplifies the initialization of global variables holding Timespans.
+func MustParseTimespan(s string) *Timespan {
+	ts, err := ParseTimespan(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ts
+}
+
+// ParseTimespanInto is like ParseTimespan except the result is stored in
+// dst instead of a newly allocated Timespan. This, along with GetTimespan
+// and PutTimespan, avoids a per-call allocation in code that parses a large
+// number of Timespan strings. If an error is returned, dst is left unchanged.
+func ParseTimespanInto(s string, dst *Timespan) error {
+	return parseTimespanInto(s, dst, &defaultParseOptions)
+}
+
+// defaultParseOptions is shared by every parse that takes no ParseOption so
+// that ParseTimespanInto needn't allocate.
+var defaultParseOptions parseOptions
+
+func parseTimespanInto(s string, dst *Timespan, opts *parseOptions) error {
+	var ts Timespan
+
+	// A blank (or sign-only) string is most likely an unset form field; we
+	// call that out explicitly rather than letting time.ParseDuration
+	// complain about it.
+	if t := strings.TrimSpace(s); t == "" || t == "-" || t == "+" {
+		return timespanError(EmptyInputErr, "empty timespan string").withTimespan(s)
+	}
+
+	if !utf8.ValidString(s) {
+		return timespanError(InvalidUTF8Err, "invalid UTF-8").withTimespan(s)
+	}
 
 	// If s contains no Timespan magnitude characters. we'll short-circuit
 	// to only parsing a time.Duration.
 	if strings.IndexAny(s, "YMWDd") == -1 {
 		var err error
-		if ts.Duration, err = time.ParseDuration(s); err != nil {
-			return nil, timespanError(badDurationErr, err.Error())
+		if ts.Duration, err = opts.parseDuration(s, 1); err != nil {
+			return timespanError(BadDurationErr, "%v", err).withTimespan(s)
+		}
+		if err := opts.checkBounds(&ts); err != nil {
+			return err.withTimespan(s)
 		}
-		return ts, nil
+		*dst = ts
+		return nil
 	}
 
 	ms := newMagset()
 
 	sign := 1
-	valid := false
-	coef := newCoefficient()
+	var coef coefficient
+	start := 0
 
 	for i, r := range s {
-		if d, err := time.ParseDuration(s[i:]); err == nil {
+		// The trailing duration may only begin where a coefficient could;
+		// probing from inside a coefficient would silently drop its leading
+		// digits (and would cost quadratic time on long digit runs). Nor can
+		// it contain a magnitude, so there's no point (or allocated error)
+		// in probing while one remains. Once none remain, the rest of s can
+		// only be the trailing duration.
+		if len(coef) == 0 && strings.IndexAny(s[i:], "YMWDd") == -1 {
+			d, err := opts.parseDuration(s[i:], sign)
+			if err != nil {
+				return timespanError(BadDurationErr, "%v", err).withTimespan(s)
+			}
 			ts.Duration = d
-			valid = true
 			break
 		}
 
-		if ok, err := coef.appendRune(r); err != nil {
-			return nil, err.withTimespan(s)
+		if len(coef) == 0 {
+			start = i
+		}
+
+		if ok, err := coef.accepts(r); err != nil {
+			return err.withTimespan(s)
 		} else if ok {
+			// Signs and digits are all single bytes.
+			coef = coefficient(s[start : i+1])
 			continue
 		}
 
 		v, err := coef.value(sign)
 		if err != nil {
-			return nil, err.withTimespan(s)
+			return err.withTimespan(s)
 		}
 
 		if err := ms.set(r, v); err != nil {
-			return nil, err.withTimespan(s)
+			return err.withTimespan(s)
 		}
 
 		if v < 0 {
@@ -213,58 +310,358 @@ func ParseTimespan(s string) (*Timespan, error) {
 			sign = 1
 		}
 
-		valid = true
-		coef = newCoefficient()
-	}
-
-	if !valid {
-		return nil, fmt.Errorf("no value derived for Timespan %q", s)
+		coef = ""
 	}
 
 	ts.Years = ms.get('Y')
 	ts.Months = ms.get('M')
+	ts.Weeks = ms.get('W')
 	ts.Days = ms.get('D')
-	ts.Days += ms.get('W') * 7
 
-	return ts, nil
+	// Weeks are applied as days so their combined total must fit in an int.
+	if _, ok := ts.totalDays(); !ok {
+		return timespanError(CoefOverflowErr, "weeks and days overflow int").withTimespan(s)
+	}
+
+	if err := opts.checkBounds(&ts); err != nil {
+		return err.withTimespan(s)
+	}
+
+	*dst = ts
+	return nil
+}
+
+// New returns a pointer to a new Timespan with the given Years, Months, Days
+// and Duration. New never sets Weeks; it is always left at zero, so
+// New(0, 0, 14, 0) is "14D" rather than "2W". To set Weeks, chain a call to
+// WithWeeks; e.g. New(1, 0, 0, 0).WithWeeks(2) is "1Y2W".
+func New(years, months, days int, d time.Duration) *Timespan {
+	return &Timespan{Years: years, Months: months, Days: days, Duration: d}
+}
+
+// ToSlice returns the members of ts as an array of int64 values in the
+// order Years, Months, Days and Duration (as a count of nanoseconds). Weeks
+// has no slot of its own and is folded into Days as 7 days per week (as with
+// Serialize), saturating if that total overflows an int64. This layout is
+// stable and is the canonical numeric representation of a Timespan; it
+// provides a reflection-free bridge to binary formats, columnar storage and
+// hash functions. FromSlice performs the inverse conversion. A nil Timespan
+// is treated as a zero span.
+func (ts *Timespan) ToSlice() [4]int64 {
+	v := ts.orZero()
+
+	days, ok := mulInt64(int64(v.Weeks), 7)
+	if ok {
+		days, ok = addInt64(days, int64(v.Days))
+	}
+	if !ok {
+		days = math.MaxInt64
+		if v.Weeks < 0 {
+			days = math.MinInt64
+		}
+	}
+
+	return [4]int64{int64(v.Years), int64(v.Months), days, int64(v.Duration)}
+}
+
+// FromSlice returns a pointer to a new Timespan whose members are taken from
+// s in the layout produced by ToSlice; its Weeks member is always zero. On
+// platforms where an int is 32 bits, calendar values that don't fit in an int
+// are truncated.
+func FromSlice(s [4]int64) *Timespan {
+	return &Timespan{
+		Years:    int(s[0]),
+		Months:   int(s[1]),
+		Days:     int(s[2]),
+		Duration: time.Duration(s[3]),
+	}
+}
+
+// FromDuration returns a pointer to a new Timespan that is the decomposition
+// of d into whole, 24 hour days plus a sub-day Duration remainder. Both parts
+// carry the same sign as d.
+//
+// Since the length of a month or year cannot be known without a reference
+// point in time, FromDuration deliberately never synthesizes Months or Years;
+// e.g. a Duration of 400 days yields a Timespan of "400D".
+func FromDuration(d time.Duration) *Timespan {
+	const day = 24 * time.Hour
+
+	return &Timespan{
+		Days:     int(d / day),
+		Duration: d % day,
+	}
+}
+
+// Between returns a pointer to a new Timespan which, when applied to from,
+// resolves to to; i.e. Between(from, to).From(from) is always equal to to.
+//
+// The result is decomposed into the largest number of whole months (reported
+// as Years and Months) followed by the largest number of whole days that may
+// be applied to from without passing to, with any remainder stored as the
+// Duration. All members share the same sign; if to is before from, each is
+// zero or negative. Calendar arithmetic is performed in from's Location.
+func Between(from, to time.Time) *Timespan {
+	sign := 1
+	if to.Before(from) {
+		sign = -1
+	}
+
+	// beyond reports whether t has passed to in the direction of travel.
+	beyond := func(t time.Time) bool {
+		if sign > 0 {
+			return t.After(to)
+		}
+		return t.Before(to)
+	}
+
+	fy, fm, _ := from.Date()
+	ty, tm, _ := to.In(from.Location()).Date()
+
+	months := (ty-fy)*12 + int(tm-fm)
+	for beyond(from.AddDate(0, months, 0)) {
+		months -= sign
+	}
+	for !beyond(from.AddDate(0, months+sign, 0)) {
+		months += sign
+	}
+
+	days := int(to.Sub(from.AddDate(0, months, 0)) / (24 * time.Hour))
+	for beyond(from.AddDate(0, months, days)) {
+		days -= sign
+	}
+	for !beyond(from.AddDate(0, months, days+sign)) {
+		days += sign
+	}
+
+	return &Timespan{
+		Years:    months / 12,
+		Months:   months % 12,
+		Days:     days,
+		Duration: to.Sub(from.AddDate(0, months, days)),
+	}
+}
+
+// Since returns the Timespan elapsed since t; it is shorthand for
+// Between(t, time.Now()). This is the calendar-aware counterpart to
+// time.Since; the result is positive if t is in the past.
+func Since(t time.Time) *Timespan {
+	return Between(t, time.Now())
+}
+
+// Until returns the Timespan remaining until t; it is shorthand for
+// Between(time.Now(), t). This is the calendar-aware counterpart to
+// time.Until; the result is positive if t is in the future.
+func Until(t time.Time) *Timespan {
+	return Between(time.Now(), t)
+}
+
+// FormatDiff returns the string form of the Timespan between before and
+// after; it is shorthand for Between(before, after).String(). For example,
+// FormatDiff(deployedAt, time.Now()) might return "2M14D". As with String,
+// the result is an empty string if before and after are equal.
+func FormatDiff(before, after time.Time) string {
+	return Between(before, after).String()
 }
 
 // IsZero returns true if the receiver is nil or if all of its component parts
 // have a zero value. Otherwise false is returned.
 func (ts *Timespan) IsZero() bool {
-	return ts == nil || (ts.Years == 0 && ts.Months == 0 && ts.Days == 0 && ts.Duration == 0)
+	return ts == nil || (ts.Years == 0 && ts.Months == 0 && ts.Weeks == 0 && ts.Days == 0 && ts.Duration == 0)
+}
+
+// HasCalendar returns true if any of the calendar members of ts (its Years,
+// Months, Weeks or Days) is non-zero, whether positive or negative. It
+// returns false for a nil Timespan.
+func (ts *Timespan) HasCalendar() bool {
+	return ts != nil && (ts.Years != 0 || ts.Months != 0 || ts.Weeks != 0 || ts.Days != 0)
+}
+
+// HasClock returns true if ts has a non-zero (positive or negative) Duration;
+// i.e. it carries a time-of-day component in addition to, or instead of,
+// any calendar members. It returns false for a nil Timespan.
+func (ts *Timespan) HasClock() bool {
+	return ts != nil && ts.Duration != 0
 }
 
-// String renders a Timespan into a form parseable by ParseTimespan.
+// String renders a Timespan into a form parseable by ParseTimespan such that
+// the parsed result is equal to ts. A zero Duration is omitted entirely while
+// a non-zero Duration is rendered by time.Duration.String (so one hour is
+// "1h0m0s"). A positive Year, Month or Day count that follows a negative one
+// is given an explicit "+" sign so the parser doesn't carry the negative sign
+// forward. A zero Timespan is rendered as an empty string and a nil Timespan
+// as "<nil>". See StringWith for other renderings.
 func (ts *Timespan) String() string {
-	s := ""
+	return ts.StringWith(RenderOptions{})
+}
 
-	if ts.Years != 0 {
-		s = fmt.Sprintf("%dY", ts.Years)
+// From returns the time.Time that results from applying the Timespan ts to the
+// point in time t.  This is shorthand for:
+//
+// 		t.AddDate(ts.Years, ts.Months, 7*ts.Weeks+ts.Days).Add(ts.Duration)
+//
+// A nil Timespan is treated as a zero span; i.e. t is returned unchanged
+// except as noted below.
+//
+// The result never carries a monotonic clock reading, even if t does (as with
+// a value from time.Now). AddDate always strips it so, for consistency, From
+// does too when ts is nil. Consequently, time.Since and time.Until measure
+// from the result using the wall clock and are subject to any adjustment of
+// the system clock. FromClamped and Before behave the same way.
+//
+// Since AddDate normalizes dates that don't exist, applying "1Y" to Feb 29,
+// 2020 yields Mar 1, 2021 (not Feb 28). Use FromClamped to avoid this or
+// SpansLeapDay to detect it.
+//
+func (ts *Timespan) From(t time.Time) time.Time {
+	if ts == nil {
+		return t.Round(0)
 	}
 
-	if ts.Months != 0 {
-		s = fmt.Sprintf("%s%dM", s, ts.Months)
+	return t.AddDate(ts.Years, ts.Months, ts.days()).Add(ts.Duration)
+}
+
+// AddDateArgs returns the calendar members of ts as the three arguments
+// expected by time.Time.AddDate, so that t.AddDate(ts.AddDateArgs()) applies
+// them directly. The returned days include 7 days for each of ts's Weeks.
+// The Duration of ts is not included and must be applied separately; i.e.
+// ts.From(t) is t.AddDate(ts.AddDateArgs()).Add(ts.Duration). A nil Timespan
+// is treated as a zero span.
+func (ts *Timespan) AddDateArgs() (years, months, days int) {
+	v := ts.orZero()
+	return v.Years, v.Months, v.days()
+}
+
+// AbsoluteAt returns the concrete duration that ts represents at Time t; i.e.
+// the elapsed time from t to ts.From(t). For example, "1M" is 672 hours at
+// February 1, 2019 but 744 hours at January 1, and "1D" is 23 hours on the
+// day that daylight saving time begins (if t's Location observes it). The
+// result is negative if ts resolves to a point before t and, as with
+// time.Time.Sub, is saturated to the maximum or minimum Duration if it
+// doesn't fit. A nil Timespan is treated as a zero span.
+//
+// This is the basis of the other methods that evaluate a Timespan at a
+// reference time, such as EqualAt, CompareAt, RatioAt and DaysAt.
+func (ts *Timespan) AbsoluteAt(t time.Time) time.Duration {
+	return ts.From(t).Sub(t)
+}
+
+// FromClamped is like From except that it never overflows into the following
+// month. If applying the Years and Months of ts to t lands on a day that
+// doesn't exist in the target month, the day is clamped to the last day of
+// that month; Days and Duration are then applied to the clamped result.
+//
+// For example, Jan 31 + "1M" is Feb 28 (or Feb 29 in a leap year) using
+// FromClamped, whereas From follows time.AddDate and yields Mar 3 (or Mar 2).
+//
+// Like From, a nil Timespan is treated as a zero span.
+func (ts *Timespan) FromClamped(t time.Time) time.Time {
+	if ts == nil {
+		return t.Round(0)
 	}
 
-	if ts.Days != 0 {
-		s = fmt.Sprintf("%s%dD", s, ts.Days)
+	return addMonthsClamped(t, 12*ts.Years+ts.Months).AddDate(0, 0, ts.days()).Add(ts.Duration)
+}
+
+// addMonthsClamped returns t moved by n calendar months with its day of the
+// month clamped to the length of the target month.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	hh, mm, ss := t.Clock()
+
+	// Day zero of the following month is the last day of the target month.
+	if last := time.Date(y, m+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day(); d > last {
+		d = last
 	}
 
-	if ts.Duration != 0 {
-		s = fmt.Sprintf("%s%v", s, ts.Duration)
+	return time.Date(y, m+time.Month(n), d, hh, mm, ss, t.Nanosecond(), t.Location())
+}
+
+// Before returns the time.Time that results from applying the Timespan ts
+// backward from the point in time t; i.e. the point in time that is ts before
+// t. Years and Months are subtracted first, clamping the day of the month to
+// the last day of the target month (see FromClamped), followed by Days and
+// then Duration.
+//
+// For example, both Mar 31 and Mar 30 less "1M" is Feb 28 (in a non-leap
+// year). See "Forward and Backward" above for why this isn't always the same
+// as applying the negated span with From.
+//
+// Before is the inverse of From for most spans but not all of them: if From
+// normalizes past the end of a month, or onto a wall-clock time skipped by a
+// DST transition, ts.Before(ts.From(t)) is not t. For example, "1M" from Jan
+// 31 is Mar 3 and "1M" before that is Feb 3.
+//
+// A nil Timespan is treated as a zero span.
+func (ts *Timespan) Before(t time.Time) time.Time {
+	if ts == nil {
+		return t.Round(0)
 	}
 
-	return s
+	return addMonthsClamped(t, -(12*ts.Years+ts.Months)).AddDate(0, 0, -ts.days()).Add(-ts.Duration)
 }
 
-// From returns the time.Time that results from applying the Timespan ts to the
-// point in time t.  This is shorthand for:
+// FromAlignedWeek applies ts to t (as with From) and then rolls the result
+// back to midnight on the most recent occurrence of weekday; if the result
+// already falls on weekday, it is rolled back to midnight that same day. This
+// yields the start of the week (beginning on weekday) that ts.From(t) falls
+// within.
+//
+// The time of day is always discarded; even a ts with a Duration yields a
+// time at midnight. Days and midnight are those of t's Location, so the
+// result is in t's Location.
+func (ts *Timespan) FromAlignedWeek(t time.Time, weekday time.Weekday) time.Time {
+	r := ts.From(t)
+	back := (int(r.Weekday()) - int(weekday) + 7) % 7
+	y, m, d := r.Date()
+
+	return time.Date(y, m, d-back, 0, 0, 0, 0, r.Location())
+}
+
+// SpansLeapDay returns true if applying ts to Time t crosses any part of
+// February 29th; that is, if the half-open range between t and ts.From(t) (in
+// either direction) overlaps a leap day in t's Location. A range that starts
+// on a leap day, such as "1Y" from Feb 29, 2020, is considered to span it.
+// A span that resolves to zero spans nothing.
+func (ts *Timespan) SpansLeapDay(t time.Time) bool {
+	lo, hi := t, ts.From(t)
+	if hi.Before(lo) {
+		lo, hi = hi, lo
+	}
+
+	if !lo.Before(hi) {
+		return false
+	}
+
+	loc := t.Location()
+
+	for y := lo.In(loc).Year(); y <= hi.In(loc).Year(); y++ {
+		day := time.Date(y, time.February, 29, 0, 0, 0, 0, loc)
+		if day.Month() != time.February {
+			continue // not a leap year
+		}
+
+		if day.Before(hi) && lo.Before(day.AddDate(0, 0, 1)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromIn is like From except that the calendar portion of ts (its Years,
+// Months and Days) is applied to t as observed in the Location loc rather
+// than in t's own Location. The Duration is then added and the result is
+// returned in t's original Location.
 //
-// 		t.AddDate(ts.Years, ts.Months, ts.Days).Add(ts.Duration)
+// This matters for spans containing days when t is held in one Location
+// (e.g. UTC) but the span is meant to follow the calendar of another. For
+// example, "1D" applied across the spring-forward transition is 23 hours in
+// America/New_York but always 24 hours in UTC.
 //
-func (ts *Timespan) From(t time.Time) time.Time {
-	return t.AddDate(ts.Years, ts.Months, ts.Days).Add(ts.Duration)
+// FromIn panics if loc is nil.
+func (ts *Timespan) FromIn(t time.Time, loc *time.Location) time.Time {
+	return ts.From(t.In(loc)).In(t.Location())
 }
 
 // Add returns a new *Timespan that is result of adding each member of ots to
@@ -274,15 +671,603 @@ func (ts *Timespan) From(t time.Time) time.Time {
 // For example, if you add two Timespan values of 8 and 9 months, the result is
 // always a Timespan value of 17 months (never 1 Year, 5 Months).
 //
+// A nil value for either ts or ots is treated as a zero span; the result is
+// never nil. No overflow checking is performed; see AddChecked.
+//
 func (ts *Timespan) Add(ots *Timespan) *Timespan {
+	a, b := ts.orZero(), ots.orZero()
+
+	return &Timespan{
+		Years:    a.Years + b.Years,
+		Months:   a.Months + b.Months,
+		Weeks:    a.Weeks + b.Weeks,
+		Days:     a.Days + b.Days,
+		Duration: a.Duration + b.Duration,
+	}
+}
+
+// Sum returns a new *Timespan that is the result of adding together each
+// member of all the given spans, just as with Add but without allocating an
+// intermediate result for each one. Nil spans are skipped and, with no (or
+// only nil) arguments, the result is a zero Timespan; it is never nil.
+func Sum(spans ...*Timespan) *Timespan {
+	sum := &Timespan{}
+
+	for _, ts := range spans {
+		if ts == nil {
+			continue
+		}
+
+		sum.Years += ts.Years
+		sum.Months += ts.Months
+		sum.Weeks += ts.Weeks
+		sum.Days += ts.Days
+		sum.Duration += ts.Duration
+	}
+
+	return sum
+}
+
+// Diff returns a new *Timespan holding the member-wise difference between ts
+// and ots (i.e. each member of ots subtracted from its counterpart in ts).
+// As with Add, no combining, reduction or carry-over is performed so the
+// result describes exactly how the two values differ; e.g. "these differ by
+// 2 months and 3 hours". For non-nil arguments, the result is a zero
+// Timespan iff ts.Equal(ots).
+//
+// A nil value for either ts or ots is treated as a zero span.
+func (ts *Timespan) Diff(ots *Timespan) *Timespan {
+	a, b := ts.orZero(), ots.orZero()
+
+	return &Timespan{
+		Years:    a.Years - b.Years,
+		Months:   a.Months - b.Months,
+		Weeks:    a.Weeks - b.Weeks,
+		Days:     a.Days - b.Days,
+		Duration: a.Duration - b.Duration,
+	}
+}
+
+// MulInt returns a new *Timespan with each member of ts multiplied by n. As
+// with Add, no combining, reduction or carry-over is performed. A zero n
+// yields a zero Timespan while a negative n also negates each member.
+//
+// Integer multiplication is exact but is not checked for overflow; a large n
+// applied to a large Duration (which is limited to roughly 292 years) will
+// silently wrap around. Use ScaleChecked to detect this.
+//
+// A nil Timespan is treated as a zero span.
+func (ts *Timespan) MulInt(n int) *Timespan {
+	v := ts.orZero()
+
 	return &Timespan{
-		Years:    ts.Years + ots.Years,
-		Months:   ts.Months + ots.Months,
-		Days:     ts.Days + ots.Days,
-		Duration: ts.Duration + ots.Duration,
+		Years:    v.Years * n,
+		Months:   v.Months * n,
+		Weeks:    v.Weeks * n,
+		Days:     v.Days * n,
+		Duration: v.Duration * time.Duration(n),
 	}
 }
 
+// DivInt returns a new *Timespan with each member of ts divided by n using
+// integer division, which truncates toward zero; any remainder is discarded.
+// For example, "7M" divided by 3 is "2M" (not "2M10D"). Since the remainder
+// is lost, ts.DivInt(n).MulInt(n) is not necessarily equal to ts. A negative
+// n also negates each member.
+//
+// DivInt panics if n is zero. A nil Timespan is treated as a zero span.
+func (ts *Timespan) DivInt(n int) *Timespan {
+	if n == 0 {
+		panic("timespan: Timespan divided by zero")
+	}
+
+	v := ts.orZero()
+
+	return &Timespan{
+		Years:    v.Years / n,
+		Months:   v.Months / n,
+		Weeks:    v.Weeks / n,
+		Days:     v.Days / n,
+		Duration: v.Duration / time.Duration(n),
+	}
+}
+
+// WithYears returns a copy of ts with its Years member replaced by n. The
+// receiver is not modified. A nil Timespan is treated as a zero span.
+func (ts *Timespan) WithYears(n int) *Timespan {
+	v := ts.orZero()
+	v.Years = n
+	return &v
+}
+
+// WithMonths returns a copy of ts with its Months member replaced by n. The
+// receiver is not modified. A nil Timespan is treated as a zero span.
+func (ts *Timespan) WithMonths(n int) *Timespan {
+	v := ts.orZero()
+	v.Months = n
+	return &v
+}
+
+// WithWeeks returns a copy of ts with its Weeks member replaced by n. The
+// receiver is not modified. A nil Timespan is treated as a zero span.
+func (ts *Timespan) WithWeeks(n int) *Timespan {
+	v := ts.orZero()
+	v.Weeks = n
+	return &v
+}
+
+// WithDays returns a copy of ts with its Days member replaced by n. The
+// receiver is not modified. A nil Timespan is treated as a zero span.
+func (ts *Timespan) WithDays(n int) *Timespan {
+	v := ts.orZero()
+	v.Days = n
+	return &v
+}
+
+// WithDuration returns a copy of ts with its Duration member replaced by d.
+// The receiver is not modified. A nil Timespan is treated as a zero span.
+func (ts *Timespan) WithDuration(d time.Duration) *Timespan {
+	v := ts.orZero()
+	v.Duration = d
+	return &v
+}
+
+// AddYears returns a copy of ts with n added to its Years member. Unlike Add,
+// which sums every member of two Timespans, only Years is changed. The
+// receiver is not modified and a nil Timespan is treated as a zero span.
+func (ts *Timespan) AddYears(n int) *Timespan {
+	v := ts.orZero()
+	v.Years += n
+	return &v
+}
+
+// AddMonths returns a copy of ts with n added to its Months member. The
+// receiver is not modified and a nil Timespan is treated as a zero span.
+func (ts *Timespan) AddMonths(n int) *Timespan {
+	v := ts.orZero()
+	v.Months += n
+	return &v
+}
+
+// AddWeeks returns a copy of ts with n added to its Weeks member. The
+// receiver is not modified and a nil Timespan is treated as a zero span.
+func (ts *Timespan) AddWeeks(n int) *Timespan {
+	v := ts.orZero()
+	v.Weeks += n
+	return &v
+}
+
+// AddDays returns a copy of ts with n added to its Days member. The receiver
+// is not modified and a nil Timespan is treated as a zero span.
+func (ts *Timespan) AddDays(n int) *Timespan {
+	v := ts.orZero()
+	v.Days += n
+	return &v
+}
+
+// AddDuration returns a copy of ts with d added to its Duration member. The
+// receiver is not modified and a nil Timespan is treated as a zero span.
+//
+// As with any time.Duration arithmetic, the sum is not checked for overflow;
+// a result beyond roughly 292 years silently wraps around.
+func (ts *Timespan) AddDuration(d time.Duration) *Timespan {
+	v := ts.orZero()
+	v.Duration += d
+	return &v
+}
+
+// Quantize returns the integer multiple of step (i.e. step.MulInt(k)) that,
+// when evaluated at Time at, resolves to the point nearest to that of ts.
+// For example, "40D" quantized to a step of "7D" is "42D". If two multiples
+// are equally near, the one farther from zero is chosen.
+//
+// Since a calendar-based step varies in length, the result depends on at:
+// "45D" quantized to "1M" is "2M" from February 1, 2019 (as 2M is 59 days and
+// 1M is 28) but "1M" from July 1, 2019 (where 1M is 31 days and 2M is 62).
+//
+// An error is returned if step resolves to zero at Time at. A nil ts is
+// treated as a zero span.
+func (ts *Timespan) Quantize(step *Timespan, at time.Time) (*Timespan, error) {
+	sd := step.AbsoluteAt(at)
+	if sd == 0 {
+		return nil, fmt.Errorf("timespan: quantization step %v resolves to zero at %v", step, at)
+	}
+
+	target := ts.From(at)
+
+	// The ratio of the resolved durations is only an estimate for calendar
+	// steps, so the neighboring multiples are considered as well.
+	k := int(math.Round(float64(target.Sub(at)) / float64(sd)))
+
+	best, bestDiff := 0, time.Duration(-1)
+	for _, c := range []int{k - 1, k, k + 1} {
+		diff := absDuration(target.Sub(step.MulInt(c).From(at)))
+
+		if bestDiff < 0 || diff < bestDiff || diff == bestDiff && absInt(c) > absInt(best) {
+			best, bestDiff = c, diff
+		}
+	}
+
+	return step.MulInt(best), nil
+}
+
+// absInt returns the absolute value of n.
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Truncate returns a copy of ts with all members smaller than the magnitude
+// mag set to zero. With a mag of 'Y' only Years is kept, 'M' keeps Years and
+// Months, 'W' keeps everything but Days and Duration, and 'D' (or 'd') zeroes
+// only the Duration. No calendar arithmetic is involved; "1M45D" truncated to
+// 'M' is simply "1M".
+//
+// Any other value for mag is not an error; Truncate returns an unmodified
+// copy of ts. A nil Timespan is treated as a zero span.
+func (ts *Timespan) Truncate(mag rune) *Timespan {
+	v := ts.orZero()
+
+	switch mag {
+	case 'Y':
+		v.Months = 0
+		fallthrough
+	case 'M':
+		v.Weeks = 0
+		fallthrough
+	case 'W':
+		v.Days = 0
+		fallthrough
+	case 'D', 'd':
+		v.Duration = 0
+	}
+
+	return &v
+}
+
+// TruncateDuration returns a copy of ts with its Duration truncated toward
+// zero to a multiple of d, as with time.Duration's Truncate method; e.g.
+// "1D2h3m4.5s" truncated to time.Minute is "1D2h3m". The calendar members
+// (Years, Months, Weeks and Days) are left untouched. A nil Timespan is
+// treated as a zero span.
+func (ts *Timespan) TruncateDuration(d time.Duration) *Timespan {
+	v := ts.orZero()
+	v.Duration = v.Duration.Truncate(d)
+	return &v
+}
+
+// RoundDuration is like TruncateDuration except that the Duration is rounded
+// to the nearest multiple of d (with halfway values rounded away from zero),
+// as with time.Duration's Round method.
+func (ts *Timespan) RoundDuration(d time.Duration) *Timespan {
+	v := ts.orZero()
+	v.Duration = v.Duration.Round(d)
+	return &v
+}
+
+// Round returns ts rounded to the nearest whole multiple of the magnitude mag
+// as evaluated at Time t. As with Truncate, mag must be one of 'Y', 'M', 'W' or
+// 'D' (or 'd') and all members smaller than mag are zero in the result; any
+// other value returns an unmodified copy of ts.
+//
+// Rounding is calendar-aware. For example, "1M15D" rounded to 'M' is "2M" from
+// January 1st (since 15 days is more than half of February) but "1M" from
+// February 1st (since it is less than half of March). Likewise, rounding to
+// 'Y' depends upon the length of the year in question and rounding to 'D'
+// upon the length of the day, which may be 23 or 25 hours across a DST
+// transition. If the two nearest candidates are equally near, the one
+// farther from t is chosen (i.e. ties round away from zero).
+// A nil Timespan is treated as a zero span.
+func (ts *Timespan) Round(mag rune, t time.Time) *Timespan {
+	var unit Timespan
+
+	switch mag {
+	case 'Y':
+		unit.Years = 1
+	case 'M':
+		unit.Months = 1
+	case 'W':
+		unit.Weeks = 1
+	case 'D', 'd':
+		unit.Days = 1
+	default:
+		v := ts.orZero()
+		return &v
+	}
+
+	kept := ts.Truncate(mag)
+	target := ts.From(t)
+	base := kept.From(t)
+
+	// As with Quantize, the ratio is only an estimate so the neighboring
+	// candidates are considered as well.
+	k := int(math.Round(float64(target.Sub(base)) / float64(unit.AbsoluteAt(base))))
+
+	var best *Timespan
+	var bestDiff, bestOff time.Duration
+
+	for _, c := range []int{k - 1, k, k + 1} {
+		cand := kept.Add(unit.MulInt(c))
+		r := cand.From(t)
+		diff, off := absDuration(target.Sub(r)), absDuration(r.Sub(t))
+
+		if best == nil || diff < bestDiff || diff == bestDiff && off > bestOff {
+			best, bestDiff, bestOff = cand, diff, off
+		}
+	}
+
+	return best
+}
+
+// absDuration returns the absolute value of d.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Normalize returns a copy of ts with whole multiples of 12 Months carried
+// into Years; e.g. "18M" becomes "1Y6M" and "-14M" becomes "-1Y-2M". Days are
+// never carried into Months since the length of a month isn't known.
+//
+// If the non-zero members of ts differ in sign (e.g. "1Y-3M"), ts is taken to
+// be deliberately stated and an unmodified copy is returned. A nil Timespan
+// is treated as a zero span.
+func (ts *Timespan) Normalize() *Timespan {
+	v := ts.orZero()
+
+	if !v.sameSign() {
+		return &v
+	}
+
+	v.Years += v.Months / 12
+	v.Months %= 12
+
+	return &v
+}
+
+// NormalForm is like Normalize except that Years and Months of differing sign
+// are also resolved by borrowing (or carrying) a year so that both share the
+// sign of their 12*Years + Months total; e.g. "1Y-3M" becomes "9M", "-1Y15M"
+// becomes "3M" and "2Y-3M" becomes "1Y9M". The result always has the same
+// TotalMonths as ts.
+//
+// Days and Duration are returned unchanged, even if their signs differ from
+// each other or from the months; neither the length of a month in days nor
+// the length of a day in time is fixed, so resolving them would require a
+// reference time. A nil Timespan is treated as a zero span.
+func (ts *Timespan) NormalForm() *Timespan {
+	v := ts.orZero()
+
+	v.Years += v.Months / 12
+	v.Months %= 12
+
+	switch {
+	case v.Years > 0 && v.Months < 0:
+		v.Years--
+		v.Months += 12
+	case v.Years < 0 && v.Months > 0:
+		v.Years++
+		v.Months -= 12
+	}
+
+	return &v
+}
+
+// Expand returns a copy of ts with its Years converted into Months; e.g.
+// "2Y3M" becomes "27M". This is the inverse of Normalize, so
+// ts.Expand().Normalize() is equal to ts for any normalized ts. Months are
+// not expanded into Days since the length of a month isn't known. A nil
+// Timespan is treated as a zero span.
+func (ts *Timespan) Expand() *Timespan {
+	v := ts.orZero()
+
+	v.Months += 12 * v.Years
+	v.Years = 0
+
+	return &v
+}
+
+// TotalMonths returns the calendar months of ts (i.e. 12*Years + Months) as a
+// single count along with the remainder of ts, which holds its Weeks, Days
+// and Duration unchanged. Signs are preserved as given; "1Y-3M" is 9 months and
+// "-1Y-3M" is -15. A nil Timespan is treated as a zero span.
+func (ts *Timespan) TotalMonths() (months int, remainder *Timespan) {
+	v := ts.orZero()
+	return 12*v.Years + v.Months, &Timespan{Weeks: v.Weeks, Days: v.Days, Duration: v.Duration}
+}
+
+// The average lengths of a year and a month in the Gregorian calendar, as
+// used by ApproximateDuration.
+const (
+	DaysPerYear  = 365.25
+	DaysPerMonth = 30.4375
+)
+
+// ApproximateDuration returns a rough estimate of the length of ts without
+// regard to any reference time. Each year is taken to be DaysPerYear days,
+// each month DaysPerMonth days, each week 7 days and each day 24 hours; e.g.
+// "1M" is 730h30m. This is suitable for coarse ordering or display but, since
+// real months, years and (across DST transitions) days vary in length, it is
+// not the exact elapsed time from any particular instant; use AbsoluteAt for
+// that. As with time.Time.Sub, a result that doesn't fit in a time.Duration
+// is saturated to the maximum or minimum Duration. A nil Timespan is treated
+// as a zero span.
+func (ts *Timespan) ApproximateDuration() time.Duration {
+	v := ts.orZero()
+
+	days := float64(v.Years)*DaysPerYear + float64(v.Months)*DaysPerMonth + 7*float64(v.Weeks) + float64(v.Days)
+	d := days*float64(24*time.Hour) + float64(v.Duration)
+
+	switch {
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	case d <= math.MinInt64:
+		return math.MinInt64
+	}
+
+	return time.Duration(d)
+}
+
+// Decompose returns the non-zero components of ts as a slice of Timespans,
+// each with exactly one non-zero field, in order from Years to Duration; e.g.
+// "1Y2M3h" yields ["1Y", "2M", "3h"]. Adding the elements back together (as
+// with Sum) reconstructs ts. A nil or zero Timespan yields an empty slice.
+func (ts *Timespan) Decompose() []*Timespan {
+	v := ts.orZero()
+
+	var parts []*Timespan
+
+	if v.Years != 0 {
+		parts = append(parts, &Timespan{Years: v.Years})
+	}
+
+	if v.Months != 0 {
+		parts = append(parts, &Timespan{Months: v.Months})
+	}
+
+	if v.Weeks != 0 {
+		parts = append(parts, &Timespan{Weeks: v.Weeks})
+	}
+
+	if v.Days != 0 {
+		parts = append(parts, &Timespan{Days: v.Days})
+	}
+
+	if v.Duration != 0 {
+		parts = append(parts, &Timespan{Duration: v.Duration})
+	}
+
+	return parts
+}
+
+// TotalDays returns the number of whole calendar days from Time at to the
+// point in time that ts resolves to from at, along with the remaining
+// Duration of less than one day. For example, "1M15D" from February 1, 2019
+// is 43 days while from March 1 it is 46. Days are counted on the calendar
+// (as with AddDate) so a day spanning a DST transition still counts as one.
+//
+// If ts resolves to a point before at, both results are zero or negative.
+// A nil Timespan is treated as a zero span.
+func (ts *Timespan) TotalDays(at time.Time) (days int, remainder time.Duration) {
+	end := ts.From(at)
+
+	sign := 1
+	if end.Before(at) {
+		sign = -1
+	}
+
+	beyond := func(n int) bool {
+		t := at.AddDate(0, 0, n)
+		return sign > 0 && t.After(end) || sign < 0 && t.Before(end)
+	}
+
+	// Estimate with 24 hour days (refining the estimate if the span is long
+	// enough to saturate a time.Duration) and then correct for DST.
+	for i := 0; i < 4; i++ {
+		adj := int(end.Sub(at.AddDate(0, 0, days)) / (24 * time.Hour))
+		if adj == 0 {
+			break
+		}
+		days += adj
+	}
+
+	for days != 0 && beyond(days) {
+		days -= sign
+	}
+
+	for !beyond(days + sign) {
+		days += sign
+	}
+
+	return days, end.Sub(at.AddDate(0, 0, days))
+}
+
+// DaysAt returns the elapsed time from Time at to the point in time that ts
+// resolves to from at, expressed as a fractional number of 24 hour days; e.g.
+// "1M" is 28 days from February 1, 2019 but 31 from January 1, and "1D12h"
+// is 1.5 days. Since the actual elapsed time is measured, a calendar day
+// that spans a DST transition counts as 23/24 or 25/24 of a day (unlike with
+// TotalDays, which counts calendar days). The result is negative if ts
+// resolves to a point before at. A nil Timespan is treated as a zero span.
+func (ts *Timespan) DaysAt(at time.Time) float64 {
+	return float64(ts.AbsoluteAt(at)) / float64(24*time.Hour)
+}
+
+// HoursAt is like DaysAt except that the elapsed time is expressed as a
+// fractional number of hours.
+func (ts *Timespan) HoursAt(at time.Time) float64 {
+	return ts.AbsoluteAt(at).Hours()
+}
+
+// sameSign returns true if none of the members of ts differ in sign.
+func (ts Timespan) sameSign() bool {
+	var pos, neg bool
+
+	for _, n := range []int64{int64(ts.Years), int64(ts.Months), int64(ts.Weeks), int64(ts.Days), int64(ts.Duration)} {
+		pos = pos || n > 0
+		neg = neg || n < 0
+	}
+
+	return !(pos && neg)
+}
+
+// Largest returns the coefficient and magnitude of the largest non-zero unit
+// in ts, as would be used for a compact label like "1y", "3mo" or "5d". The
+// first non-zero member, in order of Years ('Y'), Months ('M'), Weeks ('W')
+// and Days ('D'), is returned as-is, regardless of the value of any smaller
+// members; e.g. "1Y3M" yields (1, 'Y') and "18M" yields (18, 'M'). No
+// carry-over is performed so a 90 day span is (90, 'D').
+//
+// Only if all calendar members are zero is the Duration considered. It is
+// resolved to the largest of the following units in which its magnitude is
+// at least one, with the value truncated toward zero:
+//
+// 		'D': 24 hour days
+// 		'h': hours
+// 		'm': minutes
+// 		's': seconds
+// 		'n': nanoseconds (for any sub-second Duration)
+//
+// A zero (or nil) Timespan yields (0, 0).
+func (ts *Timespan) Largest() (value int, unit rune) {
+	v := ts.orZero()
+
+	switch {
+	case v.Years != 0:
+		return v.Years, 'Y'
+	case v.Months != 0:
+		return v.Months, 'M'
+	case v.Weeks != 0:
+		return v.Weeks, 'W'
+	case v.Days != 0:
+		return v.Days, 'D'
+	}
+
+	for _, u := range []struct {
+		size time.Duration
+		unit rune
+	}{
+		{24 * time.Hour, 'D'},
+		{time.Hour, 'h'},
+		{time.Minute, 'm'},
+		{time.Second, 's'},
+	} {
+		if n := v.Duration / u.size; n != 0 {
+			return int(n), u.unit
+		}
+	}
+
+	if v.Duration != 0 {
+		return int(v.Duration), 'n'
+	}
+
+	return 0, 0
+}
+
 // Equal determines whether two Timespans are exactly equivalent to each other.
 // Each member in ts is compared to its corresponding member in ots and all must
 // be equivalent for Equal to return true.
@@ -290,20 +1275,407 @@ func (ts *Timespan) Add(ots *Timespan) *Timespan {
 // The Timespan values of "2 Days" and "48 Hours" are never equivalent in this
 // context.
 //
+// A nil Timespan is equal only to another nil Timespan; it is not equal to
+// a (non-nil) zero value Timespan.
+//
 func (ts *Timespan) Equal(ots *Timespan) bool {
+	if ts == nil || ots == nil {
+		return ts == ots
+	}
+
 	return ts.Duration == ots.Duration &&
 		ts.Days == ots.Days &&
+		ts.Weeks == ots.Weeks &&
 		ts.Months == ots.Months &&
 		ts.Years == ots.Years
 }
 
+// EquivalentAlways reports whether ts and ots are equivalent regardless of
+// the point in time at which they are evaluated. Only two conversions hold
+// universally and so are applied before comparing: 1 year is 12 months and
+// 1 week is 7 days. Thus "1Y" is equivalent to "12M" and "2W" to "14D", but
+// "1M" is never equivalent to "30D" nor "1D" to "24h" since months vary in
+// length and days may be shortened or lengthened by DST transitions. The
+// Durations must be identical.
+//
+// As with Equal, a nil Timespan is equivalent only to another nil Timespan.
+func (ts *Timespan) EquivalentAlways(ots *Timespan) bool {
+	if ts == nil || ots == nil {
+		return ts == ots
+	}
+
+	return ts.Duration == ots.Duration &&
+		sameTotal(ts.Years, ts.Months, ots.Years, ots.Months, 12) &&
+		sameTotal(ts.Weeks, ts.Days, ots.Weeks, ots.Days, 7)
+}
+
+// sameTotal reports whether n*big1+small1 equals n*big2+small2 without
+// overflowing; n must be greater than 1.
+func sameTotal(big1, small1, big2, small2, n int) bool {
+	q1, r1 := floorDivMod(small1, n)
+	q2, r2 := floorDivMod(small2, n)
+
+	if r1 != r2 {
+		return false
+	}
+
+	// Since n > 1, q2-q1 can't overflow.
+	b, ok := addInt(big2, q2-q1)
+	return ok && b == big1
+}
+
+// floorDivMod returns the quotient of a/n, rounded toward negative infinity,
+// and the corresponding non-negative remainder; n must be positive.
+func floorDivMod(a, n int) (q, r int) {
+	q, r = a/n, a%n
+	if r < 0 {
+		q, r = q-1, r+n
+	}
+	return q, r
+}
+
 // EqualAt determines whether two Timespans are functionally equivalent.  The
 // Timespan values ts and ots are each evaluated at Time t and the result of
 // each is compared. EqualAt returns true iff the two evluations resolve to the
 // same point in time.
 //
+// Unlike Equal, a nil value for either ts or ots is treated as a zero span
+// (since that is how From evaluates it) and is therefore equivalent to any
+// Timespan that resolves to zero at Time t.
+//
 func (ts *Timespan) EqualAt(ots *Timespan, t time.Time) bool {
-	return ts.From(t).Sub(t) == ots.From(t).Sub(t)
+	return ts.AbsoluteAt(t) == ots.AbsoluteAt(t)
+}
+
+// CompareAt compares the Timespans ts and ots as evaluated at Time t. The
+// result is -1 if ts resolves to a point in time before that of ots, +1 if
+// it resolves to a later point, and 0 if they are equivalent (as with
+// EqualAt). As with From, a nil Timespan is treated as a zero span.
+func (ts *Timespan) CompareAt(ots *Timespan, t time.Time) int {
+	t1, t2 := ts.From(t), ots.From(t)
+
+	switch {
+	case t1.Before(t2):
+		return -1
+	case t1.After(t2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Compare compares the members of ts and ots in order from Years to
+// Duration, returning -1, 0 or +1 according to the first member that
+// differs; e.g. "1Y" sorts after "13M" since its Years are greater. This
+// imposes a total order consistent with Equal but, unlike CompareAt, it
+// doesn't reflect which span is actually longer. A nil Timespan is treated
+// as a zero span.
+func (ts *Timespan) Compare(ots *Timespan) int {
+	a, b := ts.orZero(), ots.orZero()
+
+	for _, p := range [][2]int64{
+		{int64(a.Years), int64(b.Years)},
+		{int64(a.Months), int64(b.Months)},
+		{int64(a.Weeks), int64(b.Weeks)},
+		{int64(a.Days), int64(b.Days)},
+		{int64(a.Duration), int64(b.Duration)},
+	} {
+		switch {
+		case p[0] < p[1]:
+			return -1
+		case p[0] > p[1]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// RatioAt returns the ratio of ts to ots as evaluated at Time t; that is, the
+// elapsed time ts resolves to from t divided by that of ots. For example,
+// "12D" is 0.4 of "1M" from April 1st (a 30 day month).
+//
+// If ots resolves to zero at t, the result follows IEEE 754 semantics: it is
+// +Inf if ts resolves to a positive span, -Inf if negative, and NaN if ts
+// also resolves to zero. As with From, a nil Timespan is treated as a zero
+// span.
+func (ts *Timespan) RatioAt(ots *Timespan, t time.Time) float64 {
+	n, d := float64(ts.AbsoluteAt(t)), float64(ots.AbsoluteAt(t))
+
+	switch {
+	case d != 0:
+		return n / d
+	case n > 0:
+		return math.Inf(1)
+	case n < 0:
+		return math.Inf(-1)
+	default:
+		return math.NaN()
+	}
+}
+
+// LerpAt linearly interpolates between ts and ots as evaluated at Time t. A
+// factor of 0 returns a copy of ts and a factor of 1 returns a copy of ots.
+// Any other factor (including those outside [0, 1], which extrapolate)
+// returns a Timespan with only its Duration set, since fractional years,
+// months and days can't be represented; that Duration is the elapsed time of
+// ts at t plus factor times the difference between that of ots and ts.
+//
+// An error is returned if factor is NaN or infinite or if the result does not
+// fit in a time.Duration. A nil Timespan is treated as a zero span.
+func (ts *Timespan) LerpAt(ots *Timespan, factor float64, t time.Time) (*Timespan, error) {
+	switch {
+	case math.IsNaN(factor) || math.IsInf(factor, 0):
+		return nil, fmt.Errorf("timespan: invalid interpolation factor %v", factor)
+	case factor == 0:
+		v := ts.orZero()
+		return &v, nil
+	case factor == 1:
+		v := ots.orZero()
+		return &v, nil
+	}
+
+	a, b := float64(ts.AbsoluteAt(t)), float64(ots.AbsoluteAt(t))
+
+	d := a + factor*(b-a)
+	if d >= math.MaxInt64 || d < math.MinInt64 {
+		return nil, fmt.Errorf("timespan: interpolation by %v overflows time.Duration", factor)
+	}
+
+	return &Timespan{Duration: time.Duration(math.Round(d))}, nil
+}
+
+// Lerp linearly interpolates, member by member, between the Timespans a and
+// b without regard to any reference time. A factor of 0 returns a copy of a
+// and a factor of 1 returns a copy of b. Factors outside [0, 1] extrapolate
+// unless clamp is true, in which case f is first clamped to [0, 1] so the
+// result never lies beyond a or b.
+//
+// Each of Years, Months, Weeks and Days is interpolated and then rounded to
+// the nearest integer (with halves rounded away from zero). So that the
+// result changes smoothly with f, the residue lost to rounding is converted
+// to a Duration, taking a year as DaysPerYear days, a month as DaysPerMonth
+// days, a week as 7 days and a day as 24 hours, and added to the
+// interpolated Duration. For example, 40% of the way from "1D" to "2D" is
+// "1D9h36m". Since real months and years (and days across DST transitions)
+// differ from these lengths, a result with rounded Years or Months may step
+// slightly backward where it rounds up; use LerpAt for an exact, Duration
+// only interpolation at a known point in time.
+//
+// Members that would overflow are saturated. As with LerpAt, an error is
+// returned if f is NaN or (unless clamp is true) infinite. A nil Timespan is
+// treated as a zero span.
+func Lerp(a, b *Timespan, f float64, clamp bool) (*Timespan, error) {
+	if clamp {
+		f = math.Max(0, math.Min(1, f))
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("timespan: invalid interpolation factor %v", f)
+	}
+
+	x, y := a.orZero(), b.orZero()
+
+	switch f {
+	case 0:
+		return &x, nil
+	case 1:
+		return &y, nil
+	}
+
+	const day = float64(24 * time.Hour)
+
+	var ts Timespan
+	var residue float64
+
+	for _, m := range []struct {
+		dst  *int
+		a, b int
+		unit float64
+	}{
+		{&ts.Years, x.Years, y.Years, DaysPerYear * day},
+		{&ts.Months, x.Months, y.Months, DaysPerMonth * day},
+		{&ts.Weeks, x.Weeks, y.Weeks, 7 * day},
+		{&ts.Days, x.Days, y.Days, day},
+	} {
+		v := float64(m.a) + f*(float64(m.b)-float64(m.a))
+		n := roundClamped(v, int64(minInt), int64(maxInt))
+		*m.dst = int(n)
+
+		// A saturated member has no meaningful residue
+		if r := math.Round(v); float64(n) == r {
+			residue += (v - r) * m.unit
+		}
+	}
+
+	// Add the interpolated change to the Duration of a so that it's exact
+	// when a and b share the same Duration, resorting to floating point only
+	// when the change is too large for that to matter.
+	dv := f*(float64(y.Duration)-float64(x.Duration)) + residue
+	if math.Abs(dv) >= 1<<62 {
+		ts.Duration = time.Duration(roundClamped(float64(x.Duration)+dv, math.MinInt64, math.MaxInt64))
+	} else if d, ok := addInt64(int64(x.Duration), int64(math.Round(dv))); ok {
+		ts.Duration = time.Duration(d)
+	} else if dv > 0 {
+		ts.Duration = math.MaxInt64
+	} else {
+		ts.Duration = math.MinInt64
+	}
+
+	return &ts, nil
+}
+
+// roundClamped rounds x to the nearest integer, with halves rounded away
+// from zero, and then clamps it to the range [lo, hi].
+func roundClamped(x float64, lo, hi int64) int64 {
+	switch x = math.Round(x); {
+	case x >= float64(hi):
+		return hi
+	case x <= float64(lo):
+		return lo
+	default:
+		return int64(x)
+	}
+}
+
+// MidpointAt returns a Timespan that resolves, at Time t, halfway between
+// the points in time that ts and ots resolve to. It is similar to LerpAt with
+// a factor of 0.5 except that it is computed exactly (rounding toward zero
+// to the nearest nanosecond) and can't fail. As with LerpAt, the result has
+// only its Duration set. A nil Timespan is treated as a zero span.
+func (ts *Timespan) MidpointAt(ots *Timespan, t time.Time) *Timespan {
+	a, b := ts.AbsoluteAt(t), ots.AbsoluteAt(t)
+
+	// Halve each before summing to avoid overflow
+	return &Timespan{Duration: truncatedMean(a/2+b/2, a%2+b%2, 2)}
+}
+
+// MaxAt returns the element of spans that resolves to the latest point in
+// time when evaluated at Time t, as determined by CompareAt. Nil elements are
+// skipped and, if several elements are equivalent at t (e.g. "1D" and "24h"
+// away from a DST transition), the first of them is returned. MaxAt returns
+// nil if spans has no non-nil elements.
+func MaxAt(spans []*Timespan, t time.Time) *Timespan {
+	return extremeAt(spans, t, 1)
+}
+
+// MinAt is like MaxAt except that it returns the element of spans that
+// resolves to the earliest point in time. Nil elements are likewise skipped
+// and the first of several equivalent elements is returned. Together with
+// SortTimespansAt, which orders the same way, MaxAt and MinAt select from a
+// list of spans such as one returned by ParseList.
+func MinAt(spans []*Timespan, t time.Time) *Timespan {
+	return extremeAt(spans, t, -1)
+}
+
+// extremeAt implements MaxAt (for a dir of 1) and MinAt (for -1).
+func extremeAt(spans []*Timespan, t time.Time, dir int) *Timespan {
+	var best *Timespan
+
+	for _, ts := range spans {
+		if ts != nil && (best == nil || ts.CompareAt(best, t) == dir) {
+			best = ts
+		}
+	}
+
+	return best
+}
+
+// AverageAt returns the arithmetic mean of spans as evaluated at Time t; i.e.
+// the mean of the elapsed time each resolves to from t. Since fractional
+// years, months and days can't be represented, the result has only its
+// Duration set. The mean is computed without overflow and is truncated
+// toward zero to the nearest nanosecond.
+//
+// The mean is taken over len(spans) with any nil element counted as a zero
+// span (as with CompareAt). If spans is empty, nil is returned.
+func AverageAt(spans []*Timespan, t time.Time) *Timespan {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// Divide each before summing to avoid overflow; the remainders are
+	// each less than n so their sum can't overflow.
+	n := time.Duration(len(spans))
+	var q, r time.Duration
+	for _, ts := range spans {
+		d := ts.AbsoluteAt(t)
+		q += d / n
+		r += d % n
+	}
+
+	return &Timespan{Duration: truncatedMean(q, r, n)}
+}
+
+// truncatedMean returns (q*n + r) / n truncated toward zero, where q and r
+// are the summed quotients and remainders of dividing some values by n.
+// The remainders may have a different sign than the total so, once they're
+// folded into q, the quotient may need stepping one toward zero.
+func truncatedMean(q, r, n time.Duration) time.Duration {
+	q += r / n
+	r %= n
+
+	switch {
+	case q > 0 && r < 0:
+		q--
+	case q < 0 && r > 0:
+		q++
+	}
+
+	return q
+}
+
+// Clamp constrains ts to the inclusive range [lo, hi] as evaluated at Time t.
+// If ts resolves to a point before lo, lo is returned; if it resolves to a
+// point after hi, hi is returned; otherwise ts itself is returned. The result
+// is one of the three arguments rather than a copy.
+//
+// Clamp panics if lo resolves to a point after hi at Time t. As with CompareAt,
+// a nil Timespan is treated as a zero span.
+func Clamp(ts, lo, hi *Timespan, t time.Time) *Timespan {
+	if lo.CompareAt(hi, t) > 0 {
+		panic(fmt.Sprintf("timespan: Clamp lower bound %v is after upper bound %v at %v", lo, hi, t))
+	}
+
+	switch {
+	case ts.CompareAt(lo, t) < 0:
+		return lo
+	case ts.CompareAt(hi, t) > 0:
+		return hi
+	default:
+		return ts
+	}
+}
+
+// Clamped is the receiver-oriented form of Clamp and is the same as:
+//
+//	Clamp(ts, lo, hi, t)
+func (ts *Timespan) Clamped(lo, hi *Timespan, t time.Time) *Timespan {
+	return Clamp(ts, lo, hi, t)
+}
+
+// days returns the number of days applied by ts; i.e. 7*Weeks + Days.
+func (ts *Timespan) days() int {
+	return 7*ts.Weeks + ts.Days
+}
+
+// totalDays is like days but also reports whether the total fits in an int.
+func (ts *Timespan) totalDays() (int, bool) {
+	wd, ok := mulInt(ts.Weeks, 7)
+	if !ok {
+		return 0, false
+	}
+	return addInt(wd, ts.Days)
+}
+
+// orZero returns the Timespan value referenced by ts, or the zero Timespan if
+// ts is nil.
+func (ts *Timespan) orZero() Timespan {
+	if ts == nil {
+		return Timespan{}
+	}
+
+	return *ts
 }
 
 // Time is a convenience alias for time.Time provided simply to act as
@@ -317,6 +1689,23 @@ func (t Time) Add(ts *Timespan) Time {
 	return Time(ts.From(time.Time(t)))
 }
 
+// AddIn returns a new Time value after applying the given Timespan in the
+// Location loc. This is the same as:
+//
+// 		Time(ts.FromIn(time.Time(t), loc))
+//
+func (t Time) AddIn(ts *Timespan, loc *time.Location) Time {
+	return Time(ts.FromIn(time.Time(t), loc))
+}
+
+// Sub returns the Timespan between u and t, decomposed into years, months and
+// days as described for Between, such that u.Add(t.Sub(u)) reproduces t. If
+// u is after t, each member of the result is zero or negative.
+//
+func (t Time) Sub(u Time) *Timespan {
+	return Between(time.Time(u), time.Time(t))
+}
+
 // TimespansEqual compares the two Timespan values in the context of this Time.
 // This is the same as:
 //
@@ -17,6 +17,9 @@ limitations under the License.
 package timespan
 
 import (
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -24,32 +27,32 @@ import (
 type testdata struct {
 	str   string
 	want  *Timespan
-	etype errType
+	etype ErrType
 }
 
 func TestParseTimespanGood(t *testing.T) {
 	data := []testdata{
-		{str: "1h30m", want: &Timespan{0, 0, 0, 1*time.Hour + 30*time.Minute}},
-		{str: "-1h30m", want: &Timespan{0, 0, 0, -1*time.Hour - 30*time.Minute}},
-		{str: "2D1h", want: &Timespan{0, 0, 2, 1 * time.Hour}},
-		{str: "4W1d", want: &Timespan{0, 0, 29, 0}},
-		{str: "4W-1d", want: &Timespan{0, 0, 27, 0}},
+		{str: "1h30m", want: &Timespan{0, 0, 0, 0, 1*time.Hour + 30*time.Minute}},
+		{str: "-1h30m", want: &Timespan{0, 0, 0, 0, -1*time.Hour - 30*time.Minute}},
+		{str: "2D1h", want: &Timespan{0, 0, 0, 2, 1 * time.Hour}},
+		{str: "4W1d", want: &Timespan{0, 0, 4, 1, 0}},
+		{str: "4W-1d", want: &Timespan{0, 0, 4, -1, 0}},
 
 		// Test sign commutation...
-		{str: "1M2D", want: &Timespan{0, 1, 2, 0}},
-		{str: "1M-2D", want: &Timespan{0, 1, -2, 0}},
-		{str: "-1M2D", want: &Timespan{0, -1, -2, 0}},
-		{str: "-1M-2D", want: &Timespan{0, -1, -2, 0}},
-		{str: "-1M+2D", want: &Timespan{0, -1, 2, 0}},
+		{str: "1M2D", want: &Timespan{0, 1, 0, 2, 0}},
+		{str: "1M-2D", want: &Timespan{0, 1, 0, -2, 0}},
+		{str: "-1M2D", want: &Timespan{0, -1, 0, -2, 0}},
+		{str: "-1M-2D", want: &Timespan{0, -1, 0, -2, 0}},
+		{str: "-1M+2D", want: &Timespan{0, -1, 0, 2, 0}},
 
 		// ...and again with weeks (since they're special)
-		{str: "1W2D", want: &Timespan{0, 0, 9, 0}},
-		{str: "1W-2D", want: &Timespan{0, 0, 5, 0}},
-		{str: "-1W2D", want: &Timespan{0, 0, -9, 0}},
-		{str: "-1W-2D", want: &Timespan{0, 0, -9, 0}},
-		{str: "-1W+2D", want: &Timespan{0, 0, -5, 0}},
+		{str: "1W2D", want: &Timespan{0, 0, 1, 2, 0}},
+		{str: "1W-2D", want: &Timespan{0, 0, 1, -2, 0}},
+		{str: "-1W2D", want: &Timespan{0, 0, -1, -2, 0}},
+		{str: "-1W-2D", want: &Timespan{0, 0, -1, -2, 0}},
+		{str: "-1W+2D", want: &Timespan{0, 0, -1, 2, 0}},
 
-		{str: "1Y2M3W4D5h6m7s89ms", want: &Timespan{1, 2, 25, 5*time.Hour + 6*time.Minute + 7*time.Second + 89*time.Millisecond}},
+		{str: "1Y2M3W4D5h6m7s89ms", want: &Timespan{1, 2, 3, 4, 5*time.Hour + 6*time.Minute + 7*time.Second + 89*time.Millisecond}},
 	}
 
 	for _, td := range data {
@@ -59,7 +62,7 @@ func TestParseTimespanGood(t *testing.T) {
 			continue
 		}
 
-		if !(td.want.Years == got.Years && td.want.Months == got.Months && td.want.Days == got.Days && td.want.Duration == got.Duration) {
+		if !(td.want.Years == got.Years && td.want.Months == got.Months && td.want.Weeks == got.Weeks && td.want.Days == got.Days && td.want.Duration == got.Duration) {
 			t.Errorf("Mismatch parsing Timespan %q  Got:%+v  Wanted:%+v", td.str, got, td.want)
 		}
 	}
@@ -67,13 +70,23 @@ func TestParseTimespanGood(t *testing.T) {
 
 func TestParseTimespanBad(t *testing.T) {
 	data := []testdata{
-		{str: "Y", etype: missingCoefErr},
-		{str: "1h2D", etype: unrecognizedMagErr},
-		{str: "1D2W", etype: magnOutOfOrderError},
-		{str: "3W2W", etype: magnRestatedErr},
-		{str: "4W1-D", etype: misplacedSignErr},
-		{str: "4W1+2D", etype: misplacedSignErr},
-		{str: "18h9", etype: badDurationErr},
+		{str: "Y", etype: MissingCoefErr},
+		{str: "1h2D", etype: UnrecognizedMagErr},
+		{str: "1D2W", etype: MagnOutOfOrderErr},
+		{str: "3W2W", etype: MagnRestatedErr},
+		{str: "4W1-D", etype: MisplacedSignErr},
+		{str: "4W1+2D", etype: MisplacedSignErr},
+		{str: "18h9", etype: BadDurationErr},
+		{str: "1D5", etype: BadDurationErr},
+		{str: "1D-", etype: BadDurationErr},
+		{str: "1D+-5h", etype: BadDurationErr},
+		{str: "1D99999999999999999999h", etype: BadDurationErr},
+		{str: "9999999999h", etype: BadDurationErr},
+		{str: "1D9999999999h", etype: BadDurationErr},
+		{str: "1D1h9999999999m", etype: BadDurationErr},
+		{str: "1D2x", etype: BadDurationErr},
+		{str: "1Y2\xffD", etype: InvalidUTF8Err},
+		{str: "\xff1h", etype: InvalidUTF8Err},
 	}
 
 	for _, td := range data {
@@ -83,23 +96,61 @@ func TestParseTimespanBad(t *testing.T) {
 			continue
 		}
 
-		if tse, ok := err.(*timespanErr); !ok {
-			t.Fatalf("Error returned while parsing invalid Timespan %q is not a timespanError!! (...I dunno what's going on :-/ )", td.str)
+		if tse, ok := err.(*ParseError); !ok {
+			t.Fatalf("Error returned while parsing invalid Timespan %q is not a ParseError!! (...I dunno what's going on :-/ )", td.str)
 		} else {
-			if tse.errorType != td.etype {
-				t.Errorf("Error mismatch parsing invalid Timespan %q: got %v; wanted %v", td.str, tse.errorType, td.etype)
+			if tse.Type() != td.etype {
+				t.Errorf("Error mismatch parsing invalid Timespan %q: got %v; wanted %v", td.str, tse.Type(), td.etype)
 			}
+
+			if tse.Input() != td.str {
+				t.Errorf("Input mismatch for ParseError from %q: got %q", td.str, tse.Input())
+			}
+
+			if want := "parsing Timespan " + strconv.Quote(td.str) + ": "; !strings.HasPrefix(tse.Error(), want) {
+				t.Errorf("Message mismatch for ParseError from %q: %q lacks prefix %q", td.str, tse.Error(), want)
+			}
+		}
+	}
+}
+
+func TestParseTimespanEmpty(t *testing.T) {
+	for _, s := range []string{"", "-", "+", " ", "\t\n", " + "} {
+		_, err := ParseTimespan(s)
+		if err == nil {
+			t.Errorf("No error found parsing empty Timespan %q: wanted:%v", s, EmptyInputErr)
+			continue
+		}
+
+		if pe, ok := err.(*ParseError); !ok {
+			t.Errorf("Error returned while parsing empty Timespan %q is not a ParseError: %v", s, err)
+		} else if pe.Type() != EmptyInputErr {
+			t.Errorf("Error mismatch parsing empty Timespan %q: got %v; wanted %v", s, pe.Type(), EmptyInputErr)
+		} else if !strings.HasSuffix(pe.Error(), "empty timespan string") {
+			t.Errorf("Message mismatch parsing empty Timespan %q: got %q", s, pe.Error())
 		}
 	}
 }
 
 func TestTimespanEqual(t *testing.T) {
-	ts1 := &Timespan{1, 2, 3, 4 * time.Hour}
-	ts2 := &Timespan{1, 2, 3, 4 * time.Hour}
+	ts1 := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	ts2 := &Timespan{1, 2, 0, 3, 4 * time.Hour}
 
 	if !ts1.Equal(ts2) {
 		t.Errorf("Timespans should be identical:\n\t 1) %v\n\t2) %v", ts1, ts2)
 	}
+
+	// Weeks are kept distinct from days by Equal but not by EqualAt.
+	wk, days := &Timespan{Weeks: 1}, &Timespan{Days: 7}
+	base := time.Date(2020, time.February, 25, 12, 0, 0, 0, time.UTC)
+
+	if wk.Equal(days) {
+		t.Errorf("(%v).Equal(%v) == true; Wanted false", wk, days)
+	}
+
+	if !wk.EqualAt(days, base) {
+		t.Errorf("(%v).EqualAt(%v, %v) == false; Wanted true", wk, days, base)
+	}
 }
 
 func TestTimespanString(t *testing.T) {
@@ -108,7 +159,7 @@ func TestTimespanString(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := "2Y2M14D2h30m0s"
+	want := "2Y2M2W2h30m0s"
 	got := ts.String()
 
 	if got != want {
@@ -116,8 +167,46 @@ func TestTimespanString(t *testing.T) {
 	}
 }
 
+func TestTimespanStringRoundTrip(t *testing.T) {
+	spans := []*Timespan{
+		{2, 2, 0, 14, 2*time.Hour + 30*time.Minute},
+		{2, 2, 0, 14, 0},
+		{Days: 3},
+		{Duration: -time.Millisecond},
+		{-1, 2, 0, 0, 0},
+		{-1, 2, 0, -3, time.Hour},
+		{1, -2, 0, 3, -time.Hour},
+		{0, -5, 0, 4, -time.Minute},
+		{-1, 0, 0, 0, time.Second},
+		{Weeks: 4, Days: 1},
+		{0, 0, -1, 2, 0},
+		{1, 2, 3, 4, time.Hour},
+	}
+
+	for _, ts := range spans {
+		s := ts.String()
+		if ts.Duration == 0 && strings.HasSuffix(s, "0s") {
+			t.Errorf("(%#v).String() == %q; Wanted no Duration component", ts, s)
+		}
+
+		got, err := ParseTimespan(s)
+		if err != nil {
+			t.Errorf("ParseTimespan((%#v).String()) failed for %q: %v", ts, s, err)
+			continue
+		}
+
+		if !got.Equal(ts) {
+			t.Errorf("ParseTimespan((%#v).String() = %q) == %#v; Wanted %#v", ts, s, got, ts)
+		}
+	}
+
+	if ts, err := ParseTimespan("4W1D"); err != nil || ts.String() != "4W1D" {
+		t.Errorf("ParseTimespan(%q).String() == %q (err: %v); Wanted %q", "4W1D", ts, err, "4W1D")
+	}
+}
+
 func TestTimespanFrom(t *testing.T) {
-	ts := &Timespan{0, 2, 14, 2*time.Hour + 30*time.Minute}
+	ts := &Timespan{0, 2, 0, 14, 2*time.Hour + 30*time.Minute}
 
 	base := time.Date(2014, 03, 03, 17, 0, 0, 0, time.UTC)
 	want := time.Date(2014, 05, 17, 19, 30, 0, 0, time.UTC)
@@ -127,13 +216,19 @@ func TestTimespanFrom(t *testing.T) {
 	if !want.Equal(got) {
 		t.Errorf("Timespan Delta Mismatch:\n\t Got: %v\n\tWant: %v", got, want)
 	}
+
+	// Weeks are applied as 7 days each.
+	wts := &Timespan{0, 2, 2, 0, 2*time.Hour + 30*time.Minute}
+	if got := wts.From(base); !want.Equal(got) {
+		t.Errorf("(%v).From(%v) == %v; Wanted %v", wts, base, got, want)
+	}
 }
 
 func TestTimespanAdd(t *testing.T) {
-	ts1 := &Timespan{0, 2, 14, 2*time.Hour + 30*time.Minute}
-	ts2 := &Timespan{1, 1, 10, 3*time.Hour + 30*time.Minute}
+	ts1 := &Timespan{0, 2, 2, 0, 2*time.Hour + 30*time.Minute}
+	ts2 := &Timespan{1, 1, 1, 3, 3*time.Hour + 30*time.Minute}
 
-	want := &Timespan{1, 3, 24, 6 * time.Hour}
+	want := &Timespan{1, 3, 3, 3, 6 * time.Hour}
 
 	got := ts1.Add(ts2)
 
@@ -148,6 +243,7 @@ func TestIsZero(t *testing.T) {
 		"empty":    &izTestcase{new(Timespan), true},
 		"years":    &izTestcase{&Timespan{Years: 1}, false},
 		"months":   &izTestcase{&Timespan{Months: 1}, false},
+		"weeks":    &izTestcase{&Timespan{Weeks: 1}, false},
 		"days":     &izTestcase{&Timespan{Days: 1}, false},
 		"duration": &izTestcase{&Timespan{Duration: 1}, false},
 	}
@@ -157,6 +253,32 @@ func TestIsZero(t *testing.T) {
 	}
 }
 
+func TestTimespanHasCalendarClock(t *testing.T) {
+	cases := []struct {
+		ts              *Timespan
+		calendar, clock bool
+	}{
+		{nil, false, false},
+		{&Timespan{}, false, false},
+		{&Timespan{Years: -1}, true, false},
+		{&Timespan{Months: 1}, true, false},
+		{&Timespan{Weeks: -2}, true, false},
+		{&Timespan{Days: 3}, true, false},
+		{&Timespan{Duration: -time.Nanosecond}, false, true},
+		{&Timespan{Days: -1, Duration: time.Hour}, true, true},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.HasCalendar(); got != tc.calendar {
+			t.Errorf("(%v).HasCalendar() == %v; Wanted %v", tc.ts, got, tc.calendar)
+		}
+
+		if got := tc.ts.HasClock(); got != tc.clock {
+			t.Errorf("(%v).HasClock() == %v; Wanted %v", tc.ts, got, tc.clock)
+		}
+	}
+}
+
 type izTestcase struct {
 	ts   *Timespan
 	want bool
@@ -167,3 +289,1685 @@ func (tc *izTestcase) test(t *testing.T) {
 		t.Errorf("(%v).IsZero() == %v; Wanted %v", tc.ts, got, tc.want)
 	}
 }
+
+func TestFromDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want *Timespan
+	}{
+		{0, &Timespan{}},
+		{23*time.Hour + 59*time.Minute, &Timespan{Duration: 23*time.Hour + 59*time.Minute}},
+		{24 * time.Hour, &Timespan{Days: 1}},
+		{50*time.Hour + 30*time.Second, &Timespan{Days: 2, Duration: 2*time.Hour + 30*time.Second}},
+		{400 * 24 * time.Hour, &Timespan{Days: 400}},
+		{-50 * time.Hour, &Timespan{Days: -2, Duration: -2 * time.Hour}},
+	}
+
+	for _, tc := range cases {
+		if got := FromDuration(tc.d); !got.Equal(tc.want) {
+			t.Errorf("FromDuration(%v) == %+v; Wanted %+v", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestErrTypeString(t *testing.T) {
+	cases := map[ErrType]string{
+		MisplacedSignErr: "MisplacedSignErr",
+		BadDurationErr:   "BadDurationErr",
+		ErrType(-1):      "ErrType(-1)",
+	}
+
+	for et, want := range cases {
+		if got := et.String(); got != want {
+			t.Errorf("ErrType(%d).String() == %q; Wanted %q", int(et), got, want)
+		}
+	}
+}
+
+func TestNilTimespan(t *testing.T) {
+	var nilts *Timespan
+	zero := &Timespan{}
+	ts := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	at := time.Date(2019, 3, 3, 17, 0, 0, 0, time.UTC)
+
+	t.Run("String", func(t *testing.T) {
+		if got := nilts.String(); got != "<nil>" {
+			t.Errorf("(nil).String() == %q; Wanted %q", got, "<nil>")
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		cases := []struct {
+			ts1, ts2 *Timespan
+			want     bool
+		}{
+			{nilts, nilts, true},
+			{nilts, zero, false},
+			{zero, nilts, false},
+			{nilts, ts, false},
+			{ts, nilts, false},
+		}
+
+		for _, tc := range cases {
+			if got := tc.ts1.Equal(tc.ts2); got != tc.want {
+				t.Errorf("(%v).Equal(%v) == %v; Wanted %v", tc.ts1, tc.ts2, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("EqualAt", func(t *testing.T) {
+		cases := []struct {
+			ts1, ts2 *Timespan
+			want     bool
+		}{
+			{nilts, nilts, true},
+			{nilts, zero, true},
+			{zero, nilts, true},
+			{nilts, ts, false},
+			{ts, nilts, false},
+		}
+
+		for _, tc := range cases {
+			if got := tc.ts1.EqualAt(tc.ts2, at); got != tc.want {
+				t.Errorf("(%v).EqualAt(%v, %v) == %v; Wanted %v", tc.ts1, tc.ts2, at, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		cases := []struct {
+			ts1, ts2, want *Timespan
+		}{
+			{nilts, nilts, zero},
+			{nilts, ts, ts},
+			{ts, nilts, ts},
+		}
+
+		for _, tc := range cases {
+			if got := tc.ts1.Add(tc.ts2); !got.Equal(tc.want) {
+				t.Errorf("(%v).Add(%v) == %v; Wanted %v", tc.ts1, tc.ts2, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("From", func(t *testing.T) {
+		if got := nilts.From(at); !got.Equal(at) {
+			t.Errorf("(nil).From(%v) == %v; Wanted %v", at, got, at)
+		}
+
+		if got := Time(at).Add(nilts); !time.Time(got).Equal(at) {
+			t.Errorf("Time(%v).Add(nil) == %v; Wanted %v", at, got, at)
+		}
+	})
+}
+
+func TestTimespanFromMonotonic(t *testing.T) {
+	now := time.Now()
+	if now == now.Round(0) {
+		t.Skip("time.Now has no monotonic clock reading on this platform")
+	}
+
+	cases := []struct {
+		name string
+		ts   *Timespan
+	}{
+		{"nil", nil},
+		{"zero", &Timespan{}},
+		{"duration", &Timespan{Duration: time.Hour}},
+		{"calendar", &Timespan{Days: 1}},
+		{"mixed", &Timespan{Months: 1, Duration: time.Minute}},
+	}
+
+	for _, tc := range cases {
+		for name, apply := range map[string]func(time.Time) time.Time{
+			"From":        tc.ts.From,
+			"FromClamped": tc.ts.FromClamped,
+			"Before":      tc.ts.Before,
+		} {
+			if got := apply(now); got != got.Round(0) {
+				t.Errorf("%s: (%v).%s(now) == %v; Wanted no monotonic clock reading", tc.name, tc.ts, name, got)
+			}
+		}
+	}
+}
+
+func TestTimespanFromClamped(t *testing.T) {
+	cases := []struct {
+		name    string
+		ts      *Timespan
+		base    time.Time
+		from    time.Time
+		clamped time.Time
+	}{
+		{"Jan31+1M", &Timespan{Months: 1}, date(2019, 1, 31, 12), date(2019, 3, 3, 12), date(2019, 2, 28, 12)},
+		{"Jan31+1M1D", &Timespan{Months: 1, Days: 1}, date(2019, 1, 31, 12), date(2019, 3, 4, 12), date(2019, 3, 1, 12)},
+		{"Jan31+1M2h", &Timespan{Months: 1, Duration: 2 * time.Hour}, date(2019, 1, 31, 12), date(2019, 3, 3, 12).Add(2 * time.Hour), date(2019, 2, 28, 12).Add(2 * time.Hour)},
+		{"Aug31-1M", &Timespan{Months: -1}, date(2019, 8, 31, 12), date(2019, 7, 31, 12), date(2019, 7, 31, 12)},
+		{"Mar31-1M", &Timespan{Months: -1}, date(2019, 3, 31, 12), date(2019, 3, 3, 12), date(2019, 2, 28, 12)},
+		{"LeapJan31+1M", &Timespan{Months: 1}, date(2020, 1, 31, 12), date(2020, 3, 2, 12), date(2020, 2, 29, 12)},
+		{"LeapFeb29+1Y", &Timespan{Years: 1}, date(2020, 2, 29, 12), date(2021, 3, 1, 12), date(2021, 2, 28, 12)},
+		{"LeapFeb29+4Y", &Timespan{Years: 4}, date(2020, 2, 29, 12), date(2024, 2, 29, 12), date(2024, 2, 29, 12)},
+		{"Dec31+2M", &Timespan{Months: 2}, date(2019, 12, 31, 12), date(2020, 3, 2, 12), date(2020, 2, 29, 12)},
+		{"Nil", nil, date(2019, 1, 31, 12), date(2019, 1, 31, 12), date(2019, 1, 31, 12)},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.From(tc.base); !got.Equal(tc.from) {
+			t.Errorf("%s: From(%v) == %v; Wanted %v", tc.name, tc.base, got, tc.from)
+		}
+
+		if got := tc.ts.FromClamped(tc.base); !got.Equal(tc.clamped) {
+			t.Errorf("%s: FromClamped(%v) == %v; Wanted %v", tc.name, tc.base, got, tc.clamped)
+		}
+	}
+}
+
+func TestTimespanDiff(t *testing.T) {
+	cases := []struct {
+		ts1, ts2, want *Timespan
+	}{
+		{&Timespan{1, 5, 0, 3, 4 * time.Hour}, &Timespan{1, 3, 0, 3, time.Hour}, &Timespan{0, 2, 0, 0, 3 * time.Hour}},
+		{&Timespan{0, 1, 0, 0, 0}, &Timespan{0, 0, 0, 30, 0}, &Timespan{0, 1, 0, -30, 0}},
+		{&Timespan{1, 2, 0, 3, 4}, &Timespan{1, 2, 0, 3, 4}, &Timespan{}},
+		{nil, &Timespan{1, 2, 0, 3, 4}, &Timespan{-1, -2, 0, -3, -4}},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts1.Diff(tc.ts2)
+		if !got.Equal(tc.want) {
+			t.Errorf("(%v).Diff(%v) == %v; Wanted %v", tc.ts1, tc.ts2, got, tc.want)
+		}
+
+		if got.IsZero() != tc.ts1.Equal(tc.ts2) {
+			t.Errorf("(%v).Diff(%v).IsZero() disagrees with Equal", tc.ts1, tc.ts2)
+		}
+	}
+
+	if got, want := (&Timespan{0, 5, 0, 0, 4 * time.Hour}).Diff(&Timespan{0, 3, 0, 0, time.Hour}).String(), "2M3h0m0s"; got != want {
+		t.Errorf("Diff rendered as %q; Wanted %q", got, want)
+	}
+}
+
+func TestTimespanCompareAt(t *testing.T) {
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		ts1, ts2 *Timespan
+		at       time.Time
+		want     int
+	}{
+		{&Timespan{Months: 1}, &Timespan{Days: 30}, feb, -1},
+		{&Timespan{Months: 1}, &Timespan{Days: 30}, mar, 1},
+		{&Timespan{Months: 1}, &Timespan{Days: 28}, feb, 0},
+		{&Timespan{Days: 2}, &Timespan{Duration: 48 * time.Hour}, mar, 0},
+		{nil, &Timespan{Duration: -1}, mar, 1},
+		{nil, nil, mar, 0},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts1.CompareAt(tc.ts2, tc.at); got != tc.want {
+			t.Errorf("(%v).CompareAt(%v, %v) == %d; Wanted %d", tc.ts1, tc.ts2, tc.at, got, tc.want)
+		}
+
+		if got := tc.ts2.CompareAt(tc.ts1, tc.at); got != -tc.want {
+			t.Errorf("(%v).CompareAt(%v, %v) == %d; Wanted %d", tc.ts2, tc.ts1, tc.at, got, -tc.want)
+		}
+	}
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("cannot load location %q: %v", name, err)
+	}
+
+	return loc
+}
+
+// date returns midnight UTC on the given day or, if hour is provided, that
+// hour of the day.
+func date(y int, m time.Month, d int, hour ...int) time.Time {
+	var h int
+	if len(hour) > 0 {
+		h = hour[0]
+	}
+
+	return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+}
+
+func TestTimespanFromIn(t *testing.T) {
+	nyc := loadLocation(t, "America/New_York")
+
+	ts := &Timespan{Days: 1}
+
+	// Noon EST on the day before the 2021 spring-forward transition.
+	base := time.Date(2021, 3, 13, 17, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		loc  *time.Location
+		want time.Duration
+	}{
+		{nyc, 23 * time.Hour},
+		{time.UTC, 24 * time.Hour},
+	}
+
+	for _, tc := range cases {
+		got := ts.FromIn(base, tc.loc)
+
+		if got.Location() != base.Location() {
+			t.Errorf("FromIn(%v, %v) returned Location %v; Wanted %v", base, tc.loc, got.Location(), base.Location())
+		}
+
+		if d := got.Sub(base); d != tc.want {
+			t.Errorf("FromIn(%v, %v) advanced %v; Wanted %v", base, tc.loc, d, tc.want)
+		}
+
+		if got2 := time.Time(Time(base).AddIn(ts, tc.loc)); !got2.Equal(got) {
+			t.Errorf("Time(%v).AddIn(%v, %v) == %v; Wanted %v", base, ts, tc.loc, got2, got)
+		}
+	}
+
+	withDur := &Timespan{Days: 1, Duration: time.Hour}
+	if got, want := withDur.FromIn(base, nyc), base.Add(24*time.Hour); !got.Equal(want) {
+		t.Errorf("(%v).FromIn(%v, %v) == %v; Wanted %v", withDur, base, nyc, got, want)
+	}
+}
+
+func TestTimespanBefore(t *testing.T) {
+	cases := []struct {
+		name string
+		ts   *Timespan
+		base time.Time
+		want time.Time
+	}{
+		{"Mar31-1M", &Timespan{Months: 1}, date(2019, 3, 31, 12), date(2019, 2, 28, 12)},
+		{"Mar30-1M", &Timespan{Months: 1}, date(2019, 3, 30, 12), date(2019, 2, 28, 12)},
+		{"LeapMar31-1M", &Timespan{Months: 1}, date(2020, 3, 31, 12), date(2020, 2, 29, 12)},
+		{"Mar31-1M1D", &Timespan{Months: 1, Days: 1}, date(2019, 3, 31, 12), date(2019, 2, 27, 12)},
+		{"Mar31-1Y1M", &Timespan{Years: 1, Months: 1}, date(2021, 3, 31, 12), date(2020, 2, 29, 12)},
+		{"Jan15-2h", &Timespan{Duration: 2 * time.Hour}, date(2019, 1, 15, 12), date(2019, 1, 15, 12).Add(-2 * time.Hour)},
+		{"Feb28+1M", &Timespan{Months: -1}, date(2019, 2, 28, 12), date(2019, 3, 28, 12)},
+		{"Nil", nil, date(2019, 3, 31, 12), date(2019, 3, 31, 12)},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.Before(tc.base); !got.Equal(tc.want) {
+			t.Errorf("%s: (%v).Before(%v) == %v; Wanted %v", tc.name, tc.ts, tc.base, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanBeforeFromRoundTrip(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		name string
+		ts   *Timespan
+		t    time.Time
+		want time.Time // ts.Before(ts.From(t)); zero means anything but t
+	}{
+		// Well-behaved spans round-trip...
+		{"mid-month", &Timespan{Months: 1}, date(2019, 1, 15), date(2019, 1, 15)},
+		{"days", &Timespan{Days: 45, Duration: time.Hour}, date(2019, 1, 31), date(2019, 1, 31)},
+		{"leap-to-leap", &Timespan{Years: 4}, date(2020, 2, 29), date(2020, 2, 29)},
+		{"clamped-month-end", &Timespan{Months: 1}, date(2019, 2, 28), date(2019, 2, 28)},
+
+		// ...but those that From normalizes past the end of a month do not.
+		{"jan-31", &Timespan{Months: 1}, date(2019, 1, 31), date(2019, 2, 3)},
+		{"jan-30", &Timespan{Months: 1}, date(2019, 1, 30), date(2019, 2, 2)},
+		{"mar-31", &Timespan{Months: 1}, date(2019, 3, 31), date(2019, 4, 1)},
+		{"leap-day", &Timespan{Years: 1}, date(2020, 2, 29), date(2020, 3, 1)},
+
+		// Nor does a wall-clock time skipped by a DST transition (which
+		// time.Date resolves to one side of the gap or the other).
+		{"dst-gap", &Timespan{Days: 1}, time.Date(2019, 3, 9, 2, 30, 0, 0, ny), time.Time{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.ts.Before(tc.ts.From(tc.t))
+
+			if tc.want.IsZero() {
+				if got.Equal(tc.t) {
+					t.Errorf("(%v).Before((%v).From(%v)) == %v; Wanted anything else", tc.ts, tc.ts, tc.t, got)
+				}
+			} else if !got.Equal(tc.want) {
+				t.Errorf("(%v).Before((%v).From(%v)) == %v; Wanted %v", tc.ts, tc.ts, tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimespanBeforeDST(t *testing.T) {
+	nyc := loadLocation(t, "America/New_York")
+
+	ts := &Timespan{Days: 1}
+	base := time.Date(2021, 3, 14, 12, 0, 0, 0, nyc)
+	want := time.Date(2021, 3, 13, 12, 0, 0, 0, nyc)
+
+	got := ts.Before(base)
+	if !got.Equal(want) {
+		t.Errorf("(%v).Before(%v) == %v; Wanted %v", ts, base, got, want)
+	}
+
+	if d := base.Sub(got); d != 23*time.Hour {
+		t.Errorf("(%v).Before(%v) moved back %v; Wanted %v", ts, base, d, 23*time.Hour)
+	}
+}
+
+func TestTimespanMulInt(t *testing.T) {
+	ts := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+
+	cases := []struct {
+		n    int
+		want *Timespan
+	}{
+		{0, &Timespan{}},
+		{1, &Timespan{1, 2, 0, 3, 4 * time.Hour}},
+		{3, &Timespan{3, 6, 0, 9, 12 * time.Hour}},
+		{-2, &Timespan{-2, -4, 0, -6, -8 * time.Hour}},
+	}
+
+	for _, tc := range cases {
+		if got := ts.MulInt(tc.n); !got.Equal(tc.want) {
+			t.Errorf("(%v).MulInt(%d) == %v; Wanted %v", ts, tc.n, got, tc.want)
+		}
+	}
+
+	if got, want := (&Timespan{Weeks: 2, Days: -1}).MulInt(-3), (&Timespan{Weeks: -6, Days: 3}); !got.Equal(want) {
+		t.Errorf("MulInt with weeks == %v; Wanted %v", got, want)
+	}
+
+	if got := (*Timespan)(nil).MulInt(5); !got.Equal(&Timespan{}) {
+		t.Errorf("(nil).MulInt(5) == %v; Wanted zero Timespan", got)
+	}
+
+	if want := (&Timespan{1, 2, 0, 3, 4 * time.Hour}); !ts.Equal(want) {
+		t.Errorf("MulInt modified its receiver: %v", ts)
+	}
+}
+
+func TestTimespanLargest(t *testing.T) {
+	cases := []struct {
+		ts    *Timespan
+		value int
+		unit  rune
+	}{
+		{&Timespan{Years: 1, Months: 3}, 1, 'Y'},
+		{&Timespan{Years: -2, Months: 30}, -2, 'Y'},
+		{&Timespan{Months: 18, Days: 400}, 18, 'M'},
+		{&Timespan{Days: 90, Duration: 100 * time.Hour}, 90, 'D'},
+		{&Timespan{Duration: 49 * time.Hour}, 2, 'D'},
+		{&Timespan{Duration: 90 * time.Minute}, 1, 'h'},
+		{&Timespan{Duration: -150 * time.Second}, -2, 'm'},
+		{&Timespan{Duration: 1500 * time.Millisecond}, 1, 's'},
+		{&Timespan{Duration: 250 * time.Millisecond}, 250000000, 'n'},
+		{&Timespan{}, 0, 0},
+		{nil, 0, 0},
+	}
+
+	for _, tc := range cases {
+		if v, u := tc.ts.Largest(); v != tc.value || u != tc.unit {
+			t.Errorf("(%v).Largest() == (%d, %q); Wanted (%d, %q)", tc.ts, v, u, tc.value, tc.unit)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	cases := []struct {
+		from, to time.Time
+		want     *Timespan
+	}{
+		{date(2019, 1, 15, 0), date(2019, 3, 20, 12), &Timespan{0, 2, 0, 5, 12 * time.Hour}},
+		{date(2019, 3, 20, 12), date(2019, 1, 15, 0), &Timespan{0, -2, 0, -5, -12 * time.Hour}},
+		{date(2019, 1, 31, 0), date(2019, 3, 1, 0), &Timespan{0, 0, 0, 29, 0}},
+		{date(2019, 1, 31, 0), date(2019, 3, 3, 0), &Timespan{0, 1, 0, 0, 0}},
+		{date(2019, 3, 31, 0), date(2019, 2, 28, 0), &Timespan{0, -1, 0, -3, 0}},
+		{date(2020, 2, 29, 0), date(2021, 2, 28, 0), &Timespan{0, 11, 0, 30, 0}},
+		{date(2020, 2, 29, 0), date(2021, 3, 1, 0), &Timespan{1, 0, 0, 0, 0}},
+		{date(2016, 5, 10, 6), date(2019, 5, 10, 5), &Timespan{2, 11, 0, 29, 23 * time.Hour}},
+		{date(2019, 5, 10, 6), date(2019, 5, 10, 6), &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		got := Between(tc.from, tc.to)
+		if !got.Equal(tc.want) {
+			t.Errorf("Between(%v, %v) == %+v; Wanted %+v", tc.from, tc.to, got, tc.want)
+		}
+
+		if to := got.From(tc.from); !to.Equal(tc.to) {
+			t.Errorf("Between(%v, %v).From(%[1]v) == %v; Wanted %[2]v", tc.from, tc.to, to)
+		}
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	cases := []struct {
+		before, after time.Time
+		want          string
+	}{
+		{date(2019, 1, 15, 0), date(2019, 3, 29, 0), "2M14D"},
+		{date(2019, 1, 15, 0), date(2019, 3, 20, 12), "2M5D12h0m0s"},
+		{date(2019, 3, 20, 12), date(2019, 1, 15, 0), "-2M-5D-12h0m0s"},
+		{date(2019, 5, 10, 6), date(2019, 5, 10, 6), ""},
+	}
+
+	for _, tc := range cases {
+		if got := FormatDiff(tc.before, tc.after); got != tc.want {
+			t.Errorf("FormatDiff(%v, %v) == %q; Wanted %q", tc.before, tc.after, got, tc.want)
+		}
+	}
+}
+
+func TestTimeSub(t *testing.T) {
+	nyc := loadLocation(t, "America/New_York")
+
+	times := []time.Time{
+		time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC),
+		time.Date(2019, 2, 28, 23, 59, 59, 0, time.UTC),
+		time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 12, 31, 18, 30, 0, 0, time.UTC),
+		time.Date(2021, 3, 13, 2, 30, 0, 0, nyc),
+		time.Date(2021, 3, 14, 3, 30, 0, 0, nyc),
+		time.Date(2021, 11, 7, 1, 30, 0, 0, nyc),
+	}
+
+	for _, t1 := range times {
+		for _, t2 := range times {
+			got := Time(t1).Sub(Time(t2))
+
+			if back := time.Time(Time(t2).Add(got)); !back.Equal(t1) {
+				t.Errorf("Time(%v).Add(Time(%v).Sub(%[1]v)) == %v; Wanted %[2]v", t2, t1, back)
+			}
+
+			neg := got.Years < 0 || got.Months < 0 || got.Days < 0 || got.Duration < 0
+			pos := got.Years > 0 || got.Months > 0 || got.Days > 0 || got.Duration > 0
+			if pos && neg {
+				t.Errorf("Time(%v).Sub(%v) == %+v has mixed signs", t1, t2, got)
+			}
+
+			if t1.Equal(t2) && !got.IsZero() {
+				t.Errorf("Time(%v).Sub(%v) == %+v; Wanted zero Timespan", t1, t2, got)
+			}
+		}
+	}
+}
+
+func TestTimespanDivInt(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		n    int
+		want *Timespan
+	}{
+		{&Timespan{Months: 7}, 3, &Timespan{Months: 2}},
+		{&Timespan{3, 6, 0, 9, 12 * time.Hour}, 3, &Timespan{1, 2, 0, 3, 4 * time.Hour}},
+		{&Timespan{3, 6, 0, 9, 12 * time.Hour}, -3, &Timespan{-1, -2, 0, -3, -4 * time.Hour}},
+		{&Timespan{-7, 7, 0, -1, 7}, 2, &Timespan{-3, 3, 0, 0, 3}},
+		{nil, 4, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.DivInt(tc.n); !got.Equal(tc.want) {
+			t.Errorf("(%v).DivInt(%d) == %v; Wanted %v", tc.ts, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanDivIntTruncation(t *testing.T) {
+	ts := &Timespan{Months: 7, Duration: 10}
+
+	// Multiplying first loses nothing...
+	if got := ts.MulInt(3).DivInt(3); !got.Equal(ts) {
+		t.Errorf("(%v).MulInt(3).DivInt(3) == %v; Wanted %v", ts, got, ts)
+	}
+
+	// ...but dividing first discards the remainder.
+	if got := ts.DivInt(3).MulInt(3); got.Equal(ts) {
+		t.Errorf("(%v).DivInt(3).MulInt(3) == %v; Wanted a truncated result", ts, got)
+	}
+}
+
+func TestTimespanDivIntByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DivInt(0) failed to panic")
+		}
+	}()
+
+	(&Timespan{Days: 1}).DivInt(0)
+}
+
+func TestClamp(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	lo := &Timespan{Duration: 5 * time.Minute}
+	hi := &Timespan{Years: 1}
+
+	cases := []struct {
+		ts   *Timespan
+		want *Timespan
+	}{
+		{&Timespan{Duration: time.Minute}, lo},
+		{nil, lo},
+		{&Timespan{Duration: 5 * time.Minute}, &Timespan{Duration: 5 * time.Minute}},
+		{&Timespan{Days: 30}, &Timespan{Days: 30}},
+		{&Timespan{Days: 365}, &Timespan{Days: 365}},
+		{&Timespan{Months: 13}, hi},
+		{&Timespan{Days: 366}, hi},
+	}
+
+	for _, tc := range cases {
+		if got := Clamp(tc.ts, lo, hi, at); !got.Equal(tc.want) {
+			t.Errorf("Clamp(%v, %v, %v, %v) == %v; Wanted %v", tc.ts, lo, hi, at, got, tc.want)
+		}
+
+		if got := tc.ts.Clamped(lo, hi, at); !got.Equal(tc.want) {
+			t.Errorf("(%v).Clamped(%v, %v, %v) == %v; Wanted %v", tc.ts, lo, hi, at, got, tc.want)
+		}
+	}
+}
+
+func TestClampAtDependsOnTime(t *testing.T) {
+	// One month is 28 days in February but 31 days in March.
+	hi := &Timespan{Months: 1}
+	ts := &Timespan{Days: 30}
+
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := Clamp(ts, nil, hi, feb); got != hi {
+		t.Errorf("Clamp(%v, nil, %v, %v) == %v; Wanted %v", ts, hi, feb, got, hi)
+	}
+
+	if got := Clamp(ts, nil, hi, mar); got != ts {
+		t.Errorf("Clamp(%v, nil, %v, %v) == %v; Wanted %v", ts, hi, mar, got, ts)
+	}
+}
+
+func TestClampInvertedBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Clamp with lo > hi failed to panic")
+		}
+	}()
+
+	Clamp(nil, &Timespan{Days: 2}, &Timespan{Days: 1}, time.Now())
+}
+
+func TestTimespanWith(t *testing.T) {
+	orig := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	saved := *orig
+
+	cases := []struct {
+		name string
+		got  *Timespan
+		want *Timespan
+	}{
+		{"WithYears", orig.WithYears(9), &Timespan{9, 2, 0, 3, 4 * time.Hour}},
+		{"WithMonths", orig.WithMonths(9), &Timespan{1, 9, 0, 3, 4 * time.Hour}},
+		{"WithWeeks", orig.WithWeeks(9), &Timespan{1, 2, 9, 3, 4 * time.Hour}},
+		{"WithDays", orig.WithDays(9), &Timespan{1, 2, 0, 9, 4 * time.Hour}},
+		{"WithDuration", orig.WithDuration(time.Minute), &Timespan{1, 2, 0, 3, time.Minute}},
+		{"chained", orig.WithYears(0).WithMonths(orig.Months + 1), &Timespan{0, 3, 0, 3, 4 * time.Hour}},
+		{"nil", (*Timespan)(nil).WithDays(2), &Timespan{Days: 2}},
+		{"nil-chained", (*Timespan)(nil).WithMonths(1).WithWeeks(2).WithDays(90), &Timespan{Months: 1, Weeks: 2, Days: 90}},
+		{"replaces", orig.WithMonths(0).WithDays(90), &Timespan{1, 0, 0, 90, 4 * time.Hour}},
+	}
+
+	for _, tc := range cases {
+		if !tc.got.Equal(tc.want) {
+			t.Errorf("%s: Got %+v; Wanted %+v", tc.name, tc.got, tc.want)
+		}
+
+		if tc.got == orig {
+			t.Errorf("%s returned its receiver", tc.name)
+		}
+	}
+
+	if *orig != saved {
+		t.Errorf("With* modified receiver: Got %+v; Wanted %+v", *orig, saved)
+	}
+}
+
+func TestTimespanAddField(t *testing.T) {
+	orig := &Timespan{1, 2, 0, 3, 4 * time.Hour}
+	saved := *orig
+
+	for _, n := range []int{-5, 0, 3} {
+		d := time.Duration(n) * time.Minute
+
+		cases := []struct {
+			name string
+			got  *Timespan
+			want Timespan
+		}{
+			{"AddYears", orig.AddYears(n), Timespan{orig.Years + n, 2, 0, 3, 4 * time.Hour}},
+			{"AddMonths", orig.AddMonths(n), Timespan{1, orig.Months + n, 0, 3, 4 * time.Hour}},
+			{"AddWeeks", orig.AddWeeks(n), Timespan{1, 2, orig.Weeks + n, 3, 4 * time.Hour}},
+			{"AddDays", orig.AddDays(n), Timespan{1, 2, 0, orig.Days + n, 4 * time.Hour}},
+			{"AddDuration", orig.AddDuration(d), Timespan{1, 2, 0, 3, orig.Duration + d}},
+		}
+
+		for _, tc := range cases {
+			if *tc.got != tc.want {
+				t.Errorf("%s(%d): Got %+v; Wanted %+v", tc.name, n, tc.got, tc.want)
+			}
+		}
+	}
+
+	if got := (*Timespan)(nil).AddMonths(3); got.Months != 3 {
+		t.Errorf("(nil).AddMonths(3).Months == %d; Wanted 3", got.Months)
+	}
+
+	if *orig != saved {
+		t.Errorf("Add* modified receiver: Got %+v; Wanted %+v", *orig, saved)
+	}
+}
+
+func TestTimespanQuantize(t *testing.T) {
+	jan := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	jul := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+	week := &Timespan{Days: 7}
+
+	cases := []struct {
+		ts   *Timespan
+		step *Timespan
+		at   time.Time
+		want *Timespan
+	}{
+		{&Timespan{Days: 40}, week, jan, &Timespan{Days: 42}},
+		{&Timespan{Days: 38}, week, jan, &Timespan{Days: 35}},
+		{&Timespan{Days: 10, Duration: 12 * time.Hour}, week, jan, &Timespan{Days: 14}},
+		{&Timespan{Days: -40}, week, jan, &Timespan{Days: -42}},
+		{&Timespan{Days: -38}, week, jan, &Timespan{Days: -35}},
+		{&Timespan{Duration: 3 * time.Hour}, week, jan, &Timespan{}},
+		{nil, week, jan, &Timespan{}},
+		{&Timespan{Days: 45}, &Timespan{Months: 1}, feb, &Timespan{Months: 2}},
+		{&Timespan{Days: 45}, &Timespan{Months: 1}, jul, &Timespan{Months: 1}},
+		{&Timespan{Days: 45}, &Timespan{Months: 1}, jan, &Timespan{Months: 2}}, // 14 days either way
+		{&Timespan{Months: 5}, &Timespan{Days: 30}, jan, &Timespan{Days: 150}},
+		{&Timespan{Years: 1}, &Timespan{Months: 5}, jan, &Timespan{Months: 10}},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.ts.Quantize(tc.step, tc.at)
+		if err != nil {
+			t.Errorf("(%v).Quantize(%v, %v) returned error: %v", tc.ts, tc.step, tc.at, err)
+			continue
+		}
+
+		if !got.Equal(tc.want) {
+			t.Errorf("(%v).Quantize(%v, %v) == %v; Wanted %v", tc.ts, tc.step, tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanQuantizeZeroStep(t *testing.T) {
+	ts := &Timespan{Days: 40}
+
+	for _, step := range []*Timespan{nil, {}, {Days: 1, Duration: -24 * time.Hour}} {
+		if got, err := ts.Quantize(step, time.Now()); err == nil {
+			t.Errorf("(%v).Quantize(%v) == %v; Wanted an error", ts, step, got)
+		}
+	}
+}
+
+func TestTimespanTruncate(t *testing.T) {
+	ts := &Timespan{1, 2, 0, 45, 4 * time.Hour}
+
+	cases := []struct {
+		mag  rune
+		want *Timespan
+	}{
+		{'Y', &Timespan{Years: 1}},
+		{'M', &Timespan{Years: 1, Months: 2}},
+		{'W', &Timespan{Years: 1, Months: 2}},
+		{'D', &Timespan{Years: 1, Months: 2, Days: 45}},
+		{'d', &Timespan{Years: 1, Months: 2, Days: 45}},
+		{'h', ts},
+		{'X', ts},
+		{0, ts},
+	}
+
+	for _, tc := range cases {
+		got := ts.Truncate(tc.mag)
+
+		if !got.Equal(tc.want) {
+			t.Errorf("(%v).Truncate(%q) == %v; Wanted %v", ts, tc.mag, got, tc.want)
+		}
+
+		if got == ts {
+			t.Errorf("(%v).Truncate(%q) returned its receiver", ts, tc.mag)
+		}
+	}
+
+	if got := (*Timespan)(nil).Truncate('M'); got == nil || !got.IsZero() {
+		t.Errorf("(nil).Truncate('M') == %v; Wanted a zero Timespan", got)
+	}
+}
+
+func TestTimespanSpansLeapDay(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		t    time.Time
+		want bool
+	}{
+		{&Timespan{Years: 1}, date(2020, 2, 29, 0), true},
+		{&Timespan{Years: 1}, date(2019, 3, 1, 0), true},
+		{&Timespan{Years: 1}, date(2020, 3, 1, 0), false},
+		{&Timespan{Years: 1}, date(2018, 6, 1, 0), false},
+		{&Timespan{Years: 4}, date(2097, 1, 1, 0), false}, // 2100 is not a leap year
+		{&Timespan{Years: 4}, date(1998, 1, 1, 0), true},  // but 2000 is
+		{&Timespan{Days: 1}, date(2020, 2, 28, 12), true},
+		{&Timespan{Days: 1}, date(2020, 2, 28, 0), false},
+		{&Timespan{Duration: time.Hour}, date(2020, 2, 29, 23), true},
+		{&Timespan{Days: -1}, date(2020, 3, 1, 0), true},
+		{&Timespan{Days: -1}, date(2020, 3, 2, 0), false},
+		{&Timespan{}, date(2020, 2, 29, 12), false},
+		{nil, date(2020, 2, 29, 12), false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.SpansLeapDay(tc.t); got != tc.want {
+			t.Errorf("(%v).SpansLeapDay(%v) == %v; Wanted %v", tc.ts, tc.t, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanFromLeapYear(t *testing.T) {
+	leap := time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)
+	ts := &Timespan{Years: 1}
+
+	if got, want := ts.From(leap), time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("(%v).From(%v) == %v; Wanted %v", ts, leap, got, want)
+	}
+
+	if got, want := ts.FromClamped(leap), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("(%v).FromClamped(%v) == %v; Wanted %v", ts, leap, got, want)
+	}
+
+	if got, want := ts.MulInt(4).From(leap), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("(%v).From(%v) == %v; Wanted %v", ts.MulInt(4), leap, got, want)
+	}
+}
+
+func TestTimespanRound(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	nyDate := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, ny)
+	}
+
+	cases := []struct {
+		name string
+		ts   *Timespan
+		mag  rune
+		at   time.Time
+		want *Timespan
+	}{
+		{"mar-31-days", &Timespan{Months: 2, Days: 20}, 'M', date(2019, 1, 1), &Timespan{Months: 3}},
+		{"feb-28-days", &Timespan{Months: 1, Days: 15}, 'M', date(2019, 1, 1), &Timespan{Months: 2}},
+		{"mar-half", &Timespan{Months: 1, Days: 15}, 'M', date(2019, 2, 1), &Timespan{Months: 1}},
+		{"feb-tie", &Timespan{Days: 14}, 'M', date(2019, 2, 1), &Timespan{Months: 1}},
+		{"feb-leap", &Timespan{Days: 14}, 'M', date(2020, 2, 1), &Timespan{}},
+		{"duration", &Timespan{Duration: 400 * time.Hour}, 'M', date(2019, 2, 1), &Timespan{Months: 1}},
+		{"negative", &Timespan{Months: -2, Days: -20}, 'M', date(2019, 5, 1), &Timespan{Months: -3}},
+		{"negative-down", &Timespan{Months: -2, Days: -10}, 'M', date(2019, 5, 1), &Timespan{Months: -2}},
+		{"mixed", &Timespan{Months: 2, Days: -20}, 'M', date(2019, 1, 1), &Timespan{Months: 1}},
+		{"year-jan", &Timespan{Years: 1, Months: 6}, 'Y', date(2019, 1, 1), &Timespan{Years: 1}},
+		{"year-jul", &Timespan{Years: 1, Months: 6}, 'Y', date(2019, 7, 1), &Timespan{Years: 2}},
+		{"year-7m", &Timespan{Months: 7}, 'Y', date(2019, 1, 1), &Timespan{Years: 1}},
+		{"day", &Timespan{Days: 3, Duration: 13 * time.Hour}, 'D', date(2019, 1, 1), &Timespan{Days: 4}},
+		{"day-d", &Timespan{Days: 3, Duration: 11 * time.Hour}, 'd', date(2019, 1, 1), &Timespan{Days: 3}},
+		{"spring-forward", &Timespan{Duration: 11*time.Hour + 45*time.Minute}, 'D', nyDate(2019, 3, 10), &Timespan{Days: 1}},
+		{"spring-before", &Timespan{Duration: 11*time.Hour + 45*time.Minute}, 'D', nyDate(2019, 3, 9), &Timespan{}},
+		{"fall-back", &Timespan{Duration: 12*time.Hour + 15*time.Minute}, 'D', nyDate(2019, 11, 3), &Timespan{}},
+		{"fall-before", &Timespan{Duration: 12*time.Hour + 15*time.Minute}, 'D', nyDate(2019, 11, 2), &Timespan{Days: 1}},
+		{"nil", nil, 'M', date(2019, 1, 1), &Timespan{}},
+		{"bad-mag", &Timespan{Days: 1, Duration: 1}, 'h', date(2019, 1, 1), &Timespan{Days: 1, Duration: 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.ts.Round(tc.mag, tc.at); !got.Equal(tc.want) {
+				t.Errorf("(%v).Round(%q, %v) == %v; Wanted %v", tc.ts, tc.mag, tc.at, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimespanNormalize(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want *Timespan
+	}{
+		{&Timespan{Months: 18}, &Timespan{Years: 1, Months: 6}},
+		{&Timespan{Months: -14}, &Timespan{Years: -1, Months: -2}},
+		{&Timespan{Months: 12}, &Timespan{Years: 1}},
+		{&Timespan{Months: -12}, &Timespan{Years: -1}},
+		{&Timespan{Months: 11}, &Timespan{Months: 11}},
+		{&Timespan{Months: 0}, &Timespan{}},
+		{&Timespan{2, 25, 0, 40, time.Hour}, &Timespan{4, 1, 0, 40, time.Hour}},
+		{&Timespan{-2, -25, 0, -40, -time.Hour}, &Timespan{-4, -1, 0, -40, -time.Hour}},
+		{&Timespan{Years: 1, Months: -3}, &Timespan{Years: 1, Months: -3}},
+		{&Timespan{Months: 15, Days: -1}, &Timespan{Months: 15, Days: -1}},
+		{&Timespan{Months: 15, Duration: -1}, &Timespan{Months: 15, Duration: -1}},
+		{nil, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.Normalize(); !got.Equal(tc.want) {
+			t.Errorf("(%v).Normalize() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanNormalForm(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want *Timespan
+	}{
+		{&Timespan{Years: 1, Months: -3}, &Timespan{Months: 9}},
+		{&Timespan{Years: -1, Months: 3}, &Timespan{Months: -9}},
+		{&Timespan{Years: -1, Months: 15}, &Timespan{Months: 3}},
+		{&Timespan{Years: 1, Months: -15}, &Timespan{Months: -3}},
+		{&Timespan{Years: 2, Months: -3}, &Timespan{Years: 1, Months: 9}},
+		{&Timespan{Years: 1, Months: -12}, &Timespan{}},
+		{&Timespan{Months: 18}, &Timespan{Years: 1, Months: 6}},
+		{&Timespan{Years: -1, Months: -14}, &Timespan{Years: -2, Months: -2}},
+		{&Timespan{1, -3, 0, -5, 2 * time.Hour}, &Timespan{0, 9, 0, -5, 2 * time.Hour}},
+		{&Timespan{Days: 3, Duration: -time.Hour}, &Timespan{Days: 3, Duration: -time.Hour}},
+		{&Timespan{Years: maxInt, Months: -1}, &Timespan{Years: maxInt - 1, Months: 11}},
+		{nil, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.NormalForm()
+		if !got.Equal(tc.want) {
+			t.Errorf("(%v).NormalForm() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+
+		if tc.ts == nil || tc.ts.Years == maxInt {
+			continue
+		}
+
+		if gm, _ := got.TotalMonths(); gm != 12*tc.ts.Years+tc.ts.Months {
+			t.Errorf("(%v).NormalForm().TotalMonths() == %d; Wanted %d", tc.ts, gm, 12*tc.ts.Years+tc.ts.Months)
+		}
+	}
+}
+
+func TestTimespanDecompose(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want []*Timespan
+	}{
+		{&Timespan{1, 2, 0, 3, 4 * time.Hour}, []*Timespan{{Years: 1}, {Months: 2}, {Days: 3}, {Duration: 4 * time.Hour}}},
+		{&Timespan{Years: 1, Months: 2, Duration: 3 * time.Hour}, []*Timespan{{Years: 1}, {Months: 2}, {Duration: 3 * time.Hour}}},
+		{&Timespan{Years: -1, Days: 5}, []*Timespan{{Years: -1}, {Days: 5}}},
+		{&Timespan{Duration: -time.Second}, []*Timespan{{Duration: -time.Second}}},
+		{&Timespan{}, nil},
+		{nil, nil},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.Decompose()
+
+		if len(got) != len(tc.want) {
+			t.Errorf("(%v).Decompose() == %v; Wanted %v", tc.ts, got, tc.want)
+			continue
+		}
+
+		for i := range got {
+			if !got[i].Equal(tc.want[i]) {
+				t.Errorf("(%v).Decompose()[%d] == %v; Wanted %v", tc.ts, i, got[i], tc.want[i])
+			}
+		}
+
+		if sum, want := Sum(got...), tc.ts.orZero(); !sum.Equal(&want) {
+			t.Errorf("Sum((%v).Decompose()...) == %v; Wanted %v", tc.ts, sum, &want)
+		}
+	}
+}
+
+func TestTimespanExpand(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want *Timespan
+	}{
+		{&Timespan{Years: 2, Months: 3}, &Timespan{Months: 27}},
+		{&Timespan{-1, -2, 0, -3, -time.Hour}, &Timespan{0, -14, 0, -3, -time.Hour}},
+		{&Timespan{Years: 1, Months: -3}, &Timespan{Months: 9}},
+		{&Timespan{Days: 40}, &Timespan{Days: 40}},
+		{nil, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.Expand(); !got.Equal(tc.want) {
+			t.Errorf("(%v).Expand() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+	}
+
+	for _, ts := range []*Timespan{{}, {Years: 2, Months: 3}, {Years: -5, Months: -11, Days: -9}, {Months: 11, Duration: time.Hour}} {
+		if got := ts.Expand().Normalize(); !got.Equal(ts) {
+			t.Errorf("(%v).Expand().Normalize() == %v; Wanted %v", ts, got, ts)
+		}
+	}
+}
+
+func TestTimespanTruncateRoundDuration(t *testing.T) {
+	noisy := 2*time.Hour + 3*time.Minute + 4500*time.Millisecond
+
+	cases := []struct {
+		ts        *Timespan
+		d         time.Duration
+		wantTrunc *Timespan
+		wantRound *Timespan
+	}{
+		{&Timespan{1, 2, 0, 3, noisy}, time.Minute, &Timespan{1, 2, 0, 3, 2*time.Hour + 3*time.Minute}, &Timespan{1, 2, 0, 3, 2*time.Hour + 3*time.Minute}},
+		{&Timespan{1, 2, 0, 3, noisy}, time.Second, &Timespan{1, 2, 0, 3, 2*time.Hour + 3*time.Minute + 4*time.Second}, &Timespan{1, 2, 0, 3, 2*time.Hour + 3*time.Minute + 5*time.Second}},
+		{&Timespan{Days: -1, Duration: -noisy}, time.Second, &Timespan{Days: -1, Duration: -2*time.Hour - 3*time.Minute - 4*time.Second}, &Timespan{Days: -1, Duration: -2*time.Hour - 3*time.Minute - 5*time.Second}},
+		{&Timespan{Days: 1, Duration: noisy}, 0, &Timespan{Days: 1, Duration: noisy}, &Timespan{Days: 1, Duration: noisy}},
+		{nil, time.Second, &Timespan{}, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.TruncateDuration(tc.d); !got.Equal(tc.wantTrunc) {
+			t.Errorf("(%v).TruncateDuration(%v) == %v; Wanted %v", tc.ts, tc.d, got, tc.wantTrunc)
+		}
+
+		if got := tc.ts.RoundDuration(tc.d); !got.Equal(tc.wantRound) {
+			t.Errorf("(%v).RoundDuration(%v) == %v; Wanted %v", tc.ts, tc.d, got, tc.wantRound)
+		}
+	}
+}
+
+func TestTimespanTotalMonths(t *testing.T) {
+	cases := []struct {
+		ts      *Timespan
+		months  int
+		remains *Timespan
+	}{
+		{&Timespan{2, 3, 0, 4, time.Hour}, 27, &Timespan{Days: 4, Duration: time.Hour}},
+		{&Timespan{-1, -3, 0, -4, -time.Hour}, -15, &Timespan{Days: -4, Duration: -time.Hour}},
+		{&Timespan{Years: 1, Months: -3, Days: 2}, 9, &Timespan{Days: 2}},
+		{&Timespan{Years: -1, Months: 14}, 2, &Timespan{}},
+		{&Timespan{Duration: time.Minute}, 0, &Timespan{Duration: time.Minute}},
+		{nil, 0, &Timespan{}},
+	}
+
+	for _, tc := range cases {
+		months, remains := tc.ts.TotalMonths()
+		if months != tc.months || !remains.Equal(tc.remains) {
+			t.Errorf("(%v).TotalMonths() == (%d, %v); Wanted (%d, %v)", tc.ts, months, remains, tc.months, tc.remains)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	a := &Timespan{1, 2, 0, 3, time.Hour}
+	b := &Timespan{-4, 5, 0, -6, time.Minute}
+	c := &Timespan{7, -8, 0, 9, -time.Second}
+
+	if got, want := Sum(a, b, c), a.Add(b).Add(c); !got.Equal(want) {
+		t.Errorf("Sum(%v, %v, %v) == %v; Wanted %v", a, b, c, got, want)
+	}
+
+	if got, want := Sum(nil, a, nil, b), a.Add(b); !got.Equal(want) {
+		t.Errorf("Sum(nil, %v, nil, %v) == %v; Wanted %v", a, b, got, want)
+	}
+
+	for _, got := range []*Timespan{Sum(), Sum(nil), Sum([]*Timespan{}...)} {
+		if got == nil || !got.IsZero() {
+			t.Errorf("empty Sum == %v; Wanted a zero Timespan", got)
+		}
+	}
+
+	if got := Sum(a); got == a {
+		t.Error("Sum returned one of its arguments")
+	}
+}
+
+var benchSum = []*Timespan{
+	{Years: 1}, {Months: 2}, {Days: 3}, {Duration: time.Hour},
+	{1, 2, 0, 3, time.Minute}, nil, {Days: -1}, {Months: 5, Days: 5},
+}
+
+func BenchmarkSum(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		Sum(benchSum...)
+	}
+}
+
+func BenchmarkSumAddLoop(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		sum := &Timespan{}
+		for _, ts := range benchSum {
+			sum = sum.Add(ts)
+		}
+	}
+}
+
+func TestMaxMinAt(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	day := &Timespan{Days: 1}
+	hours := &Timespan{Duration: 24 * time.Hour}
+	month := &Timespan{Months: 1}
+	days28 := &Timespan{Days: 28}
+	week := &Timespan{Days: 7}
+	back := &Timespan{Days: -3}
+
+	// Results are compared by identity since equivalent spans (e.g. "1M" and
+	// "28D" in February) are not Equal.
+	cases := []struct {
+		name  string
+		spans []*Timespan
+		max   *Timespan
+		min   *Timespan
+	}{
+		{"nil", nil, nil, nil},
+		{"empty", []*Timespan{}, nil, nil},
+		{"all-nil", []*Timespan{nil, nil}, nil, nil},
+		{"one", []*Timespan{week}, week, week},
+		{"mixed", []*Timespan{week, nil, day, month}, month, day},
+		{"tie-day-first", []*Timespan{day, hours, week}, week, day},
+		{"tie-hours-first", []*Timespan{hours, day, week}, week, hours},
+		{"tie-month-first", []*Timespan{week, month, days28}, month, week},
+		{"tie-days-first", []*Timespan{week, days28, month}, days28, week},
+		{"negative", []*Timespan{back, day, {Days: -1}}, day, back},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MaxAt(tc.spans, at); got != tc.max {
+				t.Errorf("MaxAt(%v, %v) == %v; Wanted %v", tc.spans, at, got, tc.max)
+			}
+
+			if got := MinAt(tc.spans, at); got != tc.min {
+				t.Errorf("MinAt(%v, %v) == %v; Wanted %v", tc.spans, at, got, tc.min)
+			}
+		})
+	}
+}
+
+func TestAverageAt(t *testing.T) {
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		spans []*Timespan
+		at    time.Time
+		want  *Timespan
+	}{
+		{"nil", nil, feb, nil},
+		{"empty", []*Timespan{}, feb, nil},
+		{"all-nil", []*Timespan{nil, nil}, feb, &Timespan{}},
+		{"one", []*Timespan{{Days: 2}}, feb, &Timespan{Duration: 48 * time.Hour}},
+		{"feb", []*Timespan{{Months: 1}, {Days: 2}}, feb, &Timespan{Duration: 15 * 24 * time.Hour}},
+		{"mar", []*Timespan{{Months: 1}, {Days: 1}}, mar, &Timespan{Duration: 16 * 24 * time.Hour}},
+		{"nil-is-zero", []*Timespan{{Duration: time.Hour}, nil, {Duration: 5 * time.Hour}}, feb, &Timespan{Duration: 2 * time.Hour}},
+		{"mixed-sign", []*Timespan{{Duration: time.Hour}, {Duration: -3 * time.Hour}}, feb, &Timespan{Duration: -time.Hour}},
+		{"mixed-sign-neg-total", []*Timespan{{Duration: 3}, {Duration: -4}}, feb, &Timespan{}},
+		{"mixed-sign-pos-total", []*Timespan{{Duration: -3}, {Duration: 4}}, feb, &Timespan{}},
+		{"mixed-sign-remainders", []*Timespan{{Duration: 5}, {Duration: -1}, {Duration: -1}}, feb, &Timespan{Duration: 1}},
+		{"truncated", []*Timespan{{Duration: 1}, {Duration: 2}, {Duration: 2}}, feb, &Timespan{Duration: 1}},
+		{"large", []*Timespan{{Duration: math.MaxInt64}, {Duration: math.MaxInt64 - 2}}, feb, &Timespan{Duration: math.MaxInt64 - 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AverageAt(tc.spans, tc.at); !got.Equal(tc.want) {
+				t.Errorf("AverageAt(%v, %v) == %v; Wanted %v", tc.spans, tc.at, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimespanTotalDays(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		ts        *Timespan
+		at        time.Time
+		days      int
+		remainder time.Duration
+	}{
+		{&Timespan{Months: 1, Days: 15}, date(2019, 2, 1), 43, 0},
+		{&Timespan{Months: 1, Days: 15}, date(2019, 3, 1), 46, 0},
+		{&Timespan{Months: 1, Days: 15}, date(2020, 2, 1), 44, 0},
+		{&Timespan{Years: 1}, date(2020, 1, 1), 366, 0},
+		{&Timespan{Years: 400}, date(2000, 1, 1), 146097, 0},
+		{&Timespan{Days: 2, Duration: 30 * time.Hour}, date(2019, 1, 1), 3, 6 * time.Hour},
+		{&Timespan{Days: 1, Duration: -2 * time.Hour}, date(2019, 1, 1), 0, 22 * time.Hour},
+		{&Timespan{Months: -1, Duration: -time.Hour}, date(2019, 3, 1), -28, -time.Hour},
+		{&Timespan{Days: 1}, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), 1, 0},
+		{&Timespan{Duration: 23 * time.Hour}, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), 1, 0},
+		{&Timespan{Duration: 24 * time.Hour}, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), 1, time.Hour},
+		{&Timespan{Duration: 24 * time.Hour}, time.Date(2019, 11, 3, 0, 0, 0, 0, ny), 0, 24 * time.Hour},
+		{nil, date(2019, 1, 1), 0, 0},
+	}
+
+	for _, tc := range cases {
+		days, rem := tc.ts.TotalDays(tc.at)
+		if days != tc.days || rem != tc.remainder {
+			t.Errorf("(%v).TotalDays(%v) == (%d, %v); Wanted (%d, %v)", tc.ts, tc.at, days, rem, tc.days, tc.remainder)
+		}
+	}
+}
+
+func TestTimespanDaysHoursAt(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		ts    *Timespan
+		at    time.Time
+		days  float64
+		hours float64
+	}{
+		{&Timespan{Months: 1}, date(2019, 2, 1), 28, 672},
+		{&Timespan{Months: 1}, date(2019, 1, 1), 31, 744},
+		{&Timespan{Months: 1}, date(2020, 2, 1), 29, 696},
+		{&Timespan{Days: 1, Duration: 12 * time.Hour}, date(2019, 1, 1), 1.5, 36},
+		{&Timespan{Weeks: 1, Duration: -6 * time.Hour}, date(2019, 1, 1), 6.75, 162},
+		{&Timespan{Months: -1}, date(2019, 3, 1), -28, -672},
+		{&Timespan{Days: 1}, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), 23.0 / 24, 23},
+		{&Timespan{Days: 1}, time.Date(2019, 11, 3, 0, 0, 0, 0, ny), 25.0 / 24, 25},
+		{nil, date(2019, 1, 1), 0, 0},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.DaysAt(tc.at); got != tc.days {
+			t.Errorf("(%v).DaysAt(%v) == %v; Wanted %v", tc.ts, tc.at, got, tc.days)
+		}
+
+		if got := tc.ts.HoursAt(tc.at); got != tc.hours {
+			t.Errorf("(%v).HoursAt(%v) == %v; Wanted %v", tc.ts, tc.at, got, tc.hours)
+		}
+	}
+}
+
+func TestTimespanRatioAt(t *testing.T) {
+	apr := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	month := &Timespan{Months: 1}
+
+	cases := []struct {
+		ts, ots *Timespan
+		at      time.Time
+		want    float64
+	}{
+		{&Timespan{Days: 12}, month, apr, 0.4},
+		{&Timespan{Days: 7}, month, feb, 0.25},
+		{month, &Timespan{Days: 7}, feb, 4},
+		{&Timespan{Days: -14}, month, feb, -0.5},
+		{nil, month, feb, 0},
+		{&Timespan{Days: 1}, nil, feb, math.Inf(1)},
+		{&Timespan{Days: -1}, &Timespan{}, feb, math.Inf(-1)},
+		{&Timespan{Days: 1}, &Timespan{Days: 1, Duration: -24 * time.Hour}, feb, math.Inf(1)},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.RatioAt(tc.ots, tc.at); got != tc.want {
+			t.Errorf("(%v).RatioAt(%v, %v) == %v; Wanted %v", tc.ts, tc.ots, tc.at, got, tc.want)
+		}
+	}
+
+	if got := (&Timespan{}).RatioAt(nil, feb); !math.IsNaN(got) {
+		t.Errorf("zero RatioAt zero == %v; Wanted NaN", got)
+	}
+}
+
+func TestTimespanLerpAt(t *testing.T) {
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	a := &Timespan{Days: 7}
+	b := &Timespan{Months: 1} // 28 days in February
+
+	cases := []struct {
+		factor float64
+		want   *Timespan
+	}{
+		{0, a},
+		{1, b},
+		{0.5, &Timespan{Duration: 17*day + 12*time.Hour}},
+		{0.25, &Timespan{Duration: 12*day + 6*time.Hour}},
+		{2, &Timespan{Duration: 49 * day}},
+		{-1, &Timespan{Duration: -14 * day}},
+	}
+
+	for _, tc := range cases {
+		got, err := a.LerpAt(b, tc.factor, feb)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("(%v).LerpAt(%v, %v, %v) == (%v, %v); Wanted (%v, nil)", a, b, tc.factor, feb, got, err, tc.want)
+		}
+
+		if got == a || got == b {
+			t.Errorf("(%v).LerpAt(%v, %v, %v) returned an argument rather than a copy", a, b, tc.factor, feb)
+		}
+	}
+
+	if got, err := (*Timespan)(nil).LerpAt(&Timespan{Duration: 10}, 0.5, feb); err != nil || got.Duration != 5 {
+		t.Errorf("(nil).LerpAt(10ns, 0.5) == (%v, %v); Wanted 5ns", got, err)
+	}
+}
+
+func TestTimespanLerpAtBad(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	a, b := &Timespan{Days: 1}, &Timespan{Days: 2}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e12} {
+		if got, err := a.LerpAt(b, f, at); err == nil || got != nil {
+			t.Errorf("(%v).LerpAt(%v, %v) == (%v, %v); Wanted an error", a, b, f, got, err)
+		}
+	}
+}
+
+func TestTimespanFromAlignedWeek(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	// June 12, 2019 was a Wednesday.
+	wed := time.Date(2019, 6, 12, 15, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		ts      *Timespan
+		t       time.Time
+		weekday time.Weekday
+		want    time.Time
+	}{
+		{"monday", &Timespan{Days: 14}, wed, time.Monday, time.Date(2019, 6, 24, 0, 0, 0, 0, time.UTC)},
+		{"sunday", &Timespan{Days: 14}, wed, time.Sunday, time.Date(2019, 6, 23, 0, 0, 0, 0, time.UTC)},
+		{"same-day", &Timespan{Days: 7}, wed, time.Wednesday, time.Date(2019, 6, 19, 0, 0, 0, 0, time.UTC)},
+		{"thursday", &Timespan{Days: 7}, wed, time.Thursday, time.Date(2019, 6, 13, 0, 0, 0, 0, time.UTC)},
+		{"duration", &Timespan{Duration: 9 * time.Hour}, wed, time.Thursday, time.Date(2019, 6, 13, 0, 0, 0, 0, time.UTC)},
+		{"negative", &Timespan{Months: -1}, wed, time.Monday, time.Date(2019, 5, 6, 0, 0, 0, 0, time.UTC)},
+		{"nil", nil, wed, time.Monday, time.Date(2019, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{"month-boundary", &Timespan{Days: 1}, time.Date(2019, 6, 30, 12, 0, 0, 0, time.UTC), time.Saturday, time.Date(2019, 6, 29, 0, 0, 0, 0, time.UTC)},
+		{"zone", &Timespan{Days: 1}, time.Date(2019, 11, 4, 1, 0, 0, 0, ny), time.Monday, time.Date(2019, 11, 4, 0, 0, 0, 0, ny)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.ts.FromAlignedWeek(tc.t, tc.weekday)
+			if !got.Equal(tc.want) || got.Location() != tc.want.Location() {
+				t.Errorf("(%v).FromAlignedWeek(%v, %v) == %v; Wanted %v", tc.ts, tc.t, tc.weekday, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimespanMidpointAt(t *testing.T) {
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	cases := []struct {
+		ts, ots *Timespan
+		want    time.Duration
+	}{
+		{&Timespan{Days: 7}, &Timespan{Months: 1}, 17*day + 12*time.Hour},
+		{&Timespan{Months: 1}, &Timespan{Days: 7}, 17*day + 12*time.Hour},
+		{&Timespan{Days: -2}, &Timespan{Days: 2}, 0},
+		{nil, &Timespan{Duration: 3}, 1},
+		{&Timespan{Duration: -3}, nil, -1},
+		{&Timespan{Duration: 3}, &Timespan{Duration: 4}, 3},
+		{&Timespan{Duration: 3}, &Timespan{Duration: -4}, 0},
+		{&Timespan{Duration: -3}, &Timespan{Duration: 4}, 0},
+		{&Timespan{Duration: 5}, &Timespan{Duration: -2}, 1},
+		{&Timespan{Duration: -5}, &Timespan{Duration: 2}, -1},
+		{&Timespan{Duration: math.MaxInt64}, &Timespan{Duration: math.MinInt64}, 0},
+		{&Timespan{Duration: math.MaxInt64}, &Timespan{Duration: math.MaxInt64}, math.MaxInt64},
+		{&Timespan{Duration: math.MinInt64}, &Timespan{Duration: math.MinInt64}, math.MinInt64},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.MidpointAt(tc.ots, feb)
+		if want := (&Timespan{Duration: tc.want}); !got.Equal(want) {
+			t.Errorf("(%v).MidpointAt(%v, %v) == %v; Wanted %v", tc.ts, tc.ots, feb, got, want)
+		}
+	}
+
+	a, b := &Timespan{Days: 7}, &Timespan{Months: 1}
+	if lerp, _ := a.LerpAt(b, 0.5, feb); !a.MidpointAt(b, feb).Equal(lerp) {
+		t.Errorf("MidpointAt disagrees with LerpAt(0.5): %v vs %v", a.MidpointAt(b, feb), lerp)
+	}
+}
+
+func TestSinceUntil(t *testing.T) {
+	past := time.Now().AddDate(-1, -2, -3).Add(-4 * time.Hour)
+	future := time.Now().AddDate(1, 2, 3).Add(4 * time.Hour)
+
+	before := time.Now()
+	since, until := Since(past), Until(future)
+	after := time.Now()
+
+	if got := since.From(past); got.Before(before) || got.After(after) {
+		t.Errorf("Since(%v) == %v; which resolves to %v, outside [%v, %v]", past, since, got, before, after)
+	}
+
+	if since.Years < 1 || since.Months < 0 || since.Days < 0 || since.Duration < 0 {
+		t.Errorf("Since(%v) == %v; Wanted a positive span of over a year", past, since)
+	}
+
+	if until.Years < 1 || until.Months < 0 || until.Days < 0 || until.Duration < 0 {
+		t.Errorf("Until(%v) == %v; Wanted a positive span of over a year", future, until)
+	}
+
+	if s := Since(future); s.Years > -1 {
+		t.Errorf("Since(%v) == %v; Wanted a negative span", future, s)
+	}
+}
+
+func TestTimespanApproximateDuration(t *testing.T) {
+	day := 24 * time.Hour
+
+	cases := []struct {
+		ts   *Timespan
+		want time.Duration
+	}{
+		{nil, 0},
+		{&Timespan{}, 0},
+		{&Timespan{Years: 1}, 365*day + 6*time.Hour},
+		{&Timespan{Years: 4}, 1461 * day},
+		{&Timespan{Months: 1}, 730*time.Hour + 30*time.Minute},
+		{&Timespan{Months: 12}, 365*day + 6*time.Hour},
+		{&Timespan{Weeks: 2, Days: 1}, 15 * day},
+		{&Timespan{Days: 1, Duration: time.Hour}, 25 * time.Hour},
+		{&Timespan{Years: -1, Months: 12}, 0},
+		{&Timespan{Months: -2, Duration: -time.Minute}, -1461*time.Hour - time.Minute},
+		{&Timespan{Years: 300}, math.MaxInt64},
+		{&Timespan{Years: -300}, math.MinInt64},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.ApproximateDuration(); got != tc.want {
+			t.Errorf("(%v).ApproximateDuration() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+	}
+}
+
+func TestTimespanAbsoluteAt(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	cases := []struct {
+		ts   *Timespan
+		at   time.Time
+		want time.Duration
+	}{
+		{&Timespan{Months: 1}, date(2019, 2, 1), 672 * time.Hour},
+		{&Timespan{Months: 1}, date(2019, 1, 1), 744 * time.Hour},
+		{&Timespan{Years: -1}, date(2021, 1, 1), -366 * 24 * time.Hour},
+		{&Timespan{Weeks: 1, Duration: time.Minute}, date(2019, 1, 1), 168*time.Hour + time.Minute},
+		{&Timespan{Days: 1}, time.Date(2019, 3, 10, 0, 0, 0, 0, ny), 23 * time.Hour},
+		{&Timespan{Years: 300}, date(2000, 1, 1), math.MaxInt64},
+		{nil, date(2019, 1, 1), 0},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.AbsoluteAt(tc.at); got != tc.want {
+			t.Errorf("(%v).AbsoluteAt(%v) == %v; Wanted %v", tc.ts, tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if got, want := New(1, -2, 3, time.Hour), (&Timespan{Years: 1, Months: -2, Days: 3, Duration: time.Hour}); !got.Equal(want) {
+		t.Errorf("New(1, -2, 3, 1h) == %+v; Wanted %+v", got, want)
+	}
+}
+
+func TestMustParseTimespan(t *testing.T) {
+	if got, want := MustParseTimespan("1W2D"), (&Timespan{Weeks: 1, Days: 2}); !got.Equal(want) {
+		t.Errorf("MustParseTimespan(%q) == %+v; Wanted %+v", "1W2D", got, want)
+	}
+
+	defer func() {
+		if _, ok := recover().(*ParseError); !ok {
+			t.Errorf("MustParseTimespan(%q) did not panic with a *ParseError", "1D2W")
+		}
+	}()
+
+	MustParseTimespan("1D2W")
+}
+
+func TestTimespanAddDateArgs(t *testing.T) {
+	at := time.Date(2019, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	for _, ts := range []*Timespan{
+		nil,
+		{1, 2, 3, 4, 5 * time.Hour},
+		{-1, 2, -3, 4, -5 * time.Hour},
+		{Weeks: 2},
+	} {
+		if got, want := at.AddDate(ts.AddDateArgs()).Add(ts.orZero().Duration), ts.From(at); !got.Equal(want) {
+			t.Errorf("AddDate((%v).AddDateArgs()) plus Duration == %v; Wanted %v", ts, got, want)
+		}
+	}
+
+	if y, m, d := (&Timespan{1, 2, 3, 4, time.Hour}).AddDateArgs(); y != 1 || m != 2 || d != 25 {
+		t.Errorf("AddDateArgs() == (%d, %d, %d); Wanted (1, 2, 25)", y, m, d)
+	}
+}
+
+func TestTimespanEquivalentAlways(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"12M", "1Y", true},
+		{"1Y-1M", "11M", true},
+		{"-1Y+13M", "1M", true},
+		{"2W", "14D", true},
+		{"1W-1D", "6D", true},
+		{"1Y2W3h", "12M14D3h", true},
+		{"30D", "1M", false},
+		{"24h", "1D", false},
+		{"1Y", "365D", false},
+		{"1W", "168h", false},
+		{"12M", "1Y1ns", false},
+	}
+
+	for _, tc := range cases {
+		a, b := MustParseTimespan(tc.a), MustParseTimespan(tc.b)
+
+		if got := a.EquivalentAlways(b); got != tc.want {
+			t.Errorf("(%v).EquivalentAlways(%v) == %v; Wanted %v", tc.a, tc.b, got, tc.want)
+		}
+
+		if got := b.EquivalentAlways(a); got != tc.want {
+			t.Errorf("(%v).EquivalentAlways(%v) == %v; Wanted %v", tc.b, tc.a, got, tc.want)
+		}
+	}
+
+	extremes := []struct {
+		a, b *Timespan
+		want bool
+	}{
+		{nil, nil, true},
+		{nil, &Timespan{}, false},
+		{&Timespan{Years: maxInt}, &Timespan{Years: maxInt - 1, Months: 12}, true},
+		{&Timespan{Years: minInt}, &Timespan{Years: maxInt}, false},
+		{&Timespan{Years: minInt, Months: maxInt}, &Timespan{Years: maxInt, Months: minInt}, false},
+		{&Timespan{Weeks: minInt}, &Timespan{Weeks: minInt + 1, Days: -7}, true},
+	}
+
+	for _, tc := range extremes {
+		if got := tc.a.EquivalentAlways(tc.b); got != tc.want {
+			t.Errorf("(%#v).EquivalentAlways(%#v) == %v; Wanted %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// lerp is Lerp for factors known to be valid.
+func lerp(t *testing.T, a, b *Timespan, f float64, clamp bool) *Timespan {
+	t.Helper()
+
+	ts, err := Lerp(a, b, f, clamp)
+	if err != nil {
+		t.Fatalf("Lerp(%v, %v, %v, %v) returned error: %v", a, b, f, clamp, err)
+	}
+
+	return ts
+}
+
+func TestLerp(t *testing.T) {
+	a := &Timespan{1, 2, 3, 4, 5 * time.Hour}
+	b := &Timespan{-2, 6, 0, 10, -time.Minute}
+
+	if got := lerp(t, a, b, 0, false); !got.Equal(a) || got == a {
+		t.Errorf("Lerp(%v, %v, 0, false) == %v; Wanted a copy of %[1]v", a, b, got)
+	}
+
+	if got := lerp(t, a, b, 1, false); !got.Equal(b) || got == b {
+		t.Errorf("Lerp(%v, %v, 1, false) == %v; Wanted a copy of %[2]v", a, b, got)
+	}
+
+	cases := []struct {
+		a, b *Timespan
+		f    float64
+		want *Timespan
+	}{
+		{&Timespan{Days: 1}, &Timespan{Days: 2}, 0.4, &Timespan{Days: 1, Duration: 9*time.Hour + 36*time.Minute}},
+		{&Timespan{Days: 1}, &Timespan{Days: 2}, 0.6, &Timespan{Days: 2, Duration: -9*time.Hour - 36*time.Minute}},
+		{&Timespan{Weeks: 2}, &Timespan{Weeks: 4}, 0.5, &Timespan{Weeks: 3}},
+		{nil, &Timespan{Months: 4}, 0.5, &Timespan{Months: 2}},
+		{nil, &Timespan{Years: 1}, 0.25, &Timespan{Duration: time.Duration(DaysPerYear / 4 * float64(24*time.Hour))}},
+		{&Timespan{Duration: time.Hour}, &Timespan{Duration: 3 * time.Hour}, 0.25, &Timespan{Duration: 90 * time.Minute}},
+		{&Timespan{Days: 1, Duration: math.MaxInt64 - 1}, &Timespan{Days: 3, Duration: math.MaxInt64 - 1}, 0.5, &Timespan{Days: 2, Duration: math.MaxInt64 - 1}},
+		{&Timespan{Days: 1}, &Timespan{Days: 2}, 3, &Timespan{Days: 4}},
+		{&Timespan{Days: 1}, &Timespan{Days: 2}, -1, &Timespan{}},
+		{&Timespan{Years: minInt}, &Timespan{Years: maxInt}, 2, &Timespan{Years: maxInt}},
+		{&Timespan{Duration: math.MinInt64}, &Timespan{Duration: math.MaxInt64}, 2, &Timespan{Duration: math.MaxInt64}},
+	}
+
+	for _, tc := range cases {
+		if got := lerp(t, tc.a, tc.b, tc.f, false); !got.Equal(tc.want) {
+			t.Errorf("Lerp(%v, %v, %v, false) == %#v; Wanted %#v", tc.a, tc.b, tc.f, got, tc.want)
+		}
+	}
+}
+
+func TestLerpClamped(t *testing.T) {
+	a, b := &Timespan{Days: 1}, &Timespan{Weeks: 1, Duration: time.Hour}
+
+	for _, tc := range []struct {
+		f    float64
+		want *Timespan
+	}{
+		{math.Inf(-1), a},
+		{-2, a},
+		{0, a},
+		{0.5, lerp(t, a, b, 0.5, false)},
+		{1, b},
+		{7, b},
+		{math.Inf(1), b},
+	} {
+		if got := lerp(t, a, b, tc.f, true); !got.Equal(tc.want) {
+			t.Errorf("Lerp(%v, %v, %v, true) == %v; Wanted %v", a, b, tc.f, got, tc.want)
+		}
+	}
+}
+
+func TestLerpMonotonic(t *testing.T) {
+	at := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, pair := range [][2]*Timespan{
+		{&Timespan{Days: 1}, &Timespan{Weeks: 3, Days: 2, Duration: 5 * time.Hour}},
+		{&Timespan{Weeks: 2, Duration: time.Hour}, &Timespan{Days: -3}},
+		{nil, &Timespan{Days: 5, Duration: -90 * time.Minute}},
+	} {
+		a, b := pair[0], pair[1]
+		dir := a.CompareAt(b, at)
+
+		prev := a
+		for f := 0.01; f <= 1; f += 0.01 {
+			cur := lerp(t, a, b, f, false)
+			if c := prev.CompareAt(cur, at); c != 0 && c != dir {
+				t.Errorf("Lerp(%v, %v, f, false) is not monotonic at f=%.2f: %v then %v", a, b, f, prev, cur)
+			}
+			prev = cur
+		}
+	}
+}
+
+func TestLerpBad(t *testing.T) {
+	a, b := &Timespan{Days: 1}, &Timespan{Days: 2}
+
+	for _, tc := range []struct {
+		f     float64
+		clamp bool
+	}{
+		{math.NaN(), false},
+		{math.Inf(1), false},
+		{math.Inf(-1), false},
+		{math.NaN(), true},
+	} {
+		if got, err := Lerp(a, b, tc.f, tc.clamp); err == nil {
+			t.Errorf("Lerp(%v, %v, %v, %v) == %v; Wanted an error", a, b, tc.f, tc.clamp, got)
+		}
+	}
+}
+
+func TestTimespanToFromSlice(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want [4]int64
+	}{
+		{nil, [4]int64{}},
+		{&Timespan{}, [4]int64{}},
+		{&Timespan{1, -2, 0, -4, 5 * time.Second}, [4]int64{1, -2, -4, 5e9}},
+		{&Timespan{maxInt, minInt, 0, maxInt, math.MinInt64}, [4]int64{int64(maxInt), int64(minInt), int64(maxInt), math.MinInt64}},
+	}
+
+	for _, tc := range cases {
+		got := tc.ts.ToSlice()
+		if got != tc.want {
+			t.Errorf("(%v).ToSlice() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+
+		want := tc.ts.orZero()
+		if back := FromSlice(got); !back.Equal(&want) {
+			t.Errorf("FromSlice(%v) == %v; Wanted %v", got, back, &want)
+		}
+	}
+}
+
+func TestTimespanToSliceWeeks(t *testing.T) {
+	cases := []struct {
+		ts   *Timespan
+		want [4]int64
+	}{
+		{&Timespan{1, -2, 3, -4, 5 * time.Second}, [4]int64{1, -2, 17, 5e9}},
+		{&Timespan{Weeks: -2, Days: 1}, [4]int64{0, 0, -13, 0}},
+	}
+
+	// Totals beyond the range of an int64 saturate.
+	if maxInt != math.MaxInt32 {
+		cases = append(cases, []struct {
+			ts   *Timespan
+			want [4]int64
+		}{
+			{&Timespan{Weeks: maxInt}, [4]int64{0, 0, math.MaxInt64, 0}},
+			{&Timespan{Weeks: minInt}, [4]int64{0, 0, math.MinInt64, 0}},
+			{&Timespan{Weeks: maxInt / 7, Days: maxInt}, [4]int64{0, 0, math.MaxInt64, 0}},
+		}...)
+	}
+
+	for _, tc := range cases {
+		if got := tc.ts.ToSlice(); got != tc.want {
+			t.Errorf("(%v).ToSlice() == %v; Wanted %v", tc.ts, got, tc.want)
+		}
+
+		want := &Timespan{Years: tc.ts.Years, Months: tc.ts.Months, Days: int(tc.want[2]), Duration: tc.ts.Duration}
+		if back := FromSlice(tc.ts.ToSlice()); !back.Equal(want) {
+			t.Errorf("FromSlice((%v).ToSlice()) == %v; Wanted %v", tc.ts, back, want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tsbson
+
+go 1.18
+
+require (
+	go.mongodb.org/mongo-driver/v2 v2.2.1
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
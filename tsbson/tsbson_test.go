@@ -0,0 +1,79 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsbson
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"toolman.org/time/timespan/v2"
+)
+
+type account struct {
+	TTL     timespan.Timespan  `bson:"ttl"`
+	Grace   *timespan.Timespan `bson:"grace"`
+	Missing *timespan.Timespan `bson:"missing,omitempty"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := account{
+		TTL:   timespan.Timespan{Days: 30},
+		Grace: &timespan.Timespan{Years: 1, Months: 6, Duration: 2 * time.Hour},
+	}
+
+	for _, v := range []interface{}{want, &want} {
+		out, err := bson.Marshal(v)
+		if err != nil {
+			t.Fatalf("bson.Marshal(%+v): %v", v, err)
+		}
+
+		for key, str := range map[string]string{"ttl": "30D", "grace": "1Y6M2h0m0s"} {
+			rv := bson.Raw(out).Lookup(key)
+			if rv.Type != bson.TypeString || rv.StringValue() != str {
+				t.Errorf("bson.Marshal(%+v): %q == %v; Wanted the string %q", v, key, rv, str)
+			}
+		}
+
+		var got account
+		if err := bson.Unmarshal(out, &got); err != nil {
+			t.Fatalf("bson.Unmarshal(%v): %v", bson.Raw(out), err)
+		}
+
+		if !got.TTL.Equal(&want.TTL) || !got.Grace.Equal(want.Grace) || got.Missing != nil {
+			t.Errorf("BSON round-trip mismatch: Got %+v; Wanted %+v", got, want)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, doc := range []bson.D{
+		{{Key: "ttl", Value: "1D2W"}},
+		{{Key: "ttl", Value: int32(5)}},
+		{{Key: "ttl", Value: bson.D{{Key: "days", Value: int32(1)}}}},
+	} {
+		in, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatalf("bson.Marshal(%v): %v", doc, err)
+		}
+
+		var got account
+		if err := bson.Unmarshal(in, &got); err == nil {
+			t.Errorf("bson.Unmarshal(%v) == %+v; Wanted an error", doc, got)
+		}
+	}
+}
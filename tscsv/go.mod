@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tscsv
+
+go 1.18
+
+require (
+	github.com/gocarina/gocsv v0.0.0-20240520201108-78e41c74b4b1
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tscsv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"toolman.org/time/timespan/v2"
+)
+
+type account struct {
+	Name   string             `csv:"name"`
+	Cycle  timespan.Timespan  `csv:"cycle"`
+	Grace  *timespan.Timespan `csv:"grace"`
+	Notice timespan.Timespan  `csv:"notice"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := []*account{
+		{Name: "monthly", Cycle: timespan.Timespan{Months: 1}, Grace: &timespan.Timespan{Days: 3}},
+		{Name: "odd", Cycle: timespan.Timespan{Years: 1, Months: 6, Duration: 2 * time.Hour}, Notice: timespan.Timespan{Weeks: -1, Days: 2}},
+		{Name: "empty"},
+	}
+
+	out, err := gocsv.MarshalString(&want)
+	if err != nil {
+		t.Fatalf("gocsv.MarshalString: %v", err)
+	}
+
+	wantCSV := "name,cycle,grace,notice\nmonthly,1M,3D,\nodd,1Y6M2h0m0s,,-1W+2D\nempty,,,\n"
+	if out != wantCSV {
+		t.Errorf("gocsv.MarshalString() == %q; Wanted %q", out, wantCSV)
+	}
+
+	var got []*account
+	if err := gocsv.UnmarshalString(out, &got); err != nil {
+		t.Fatalf("gocsv.UnmarshalString(%q): %v", out, err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("gocsv.UnmarshalString(%q) returned %d rows; Wanted %d", out, len(got), len(want))
+	}
+
+	for i, g := range got {
+		w := want[i]
+		if g.Name != w.Name || !g.Cycle.Equal(&w.Cycle) || !g.Notice.Equal(&w.Notice) || !equalOrNil(g.Grace, w.Grace) {
+			t.Errorf("CSV round-trip mismatch for row %d: Got %+v; Wanted %+v", i, g, w)
+		}
+	}
+}
+
+// equalOrNil reports whether a and b are equal, treating a nil Timespan as
+// equal to a zero one since gocsv decodes every cell into a new value.
+func equalOrNil(a, b *timespan.Timespan) bool {
+	zero := &timespan.Timespan{}
+	if a == nil {
+		a = zero
+	}
+	if b == nil {
+		b = zero
+	}
+	return a.Equal(b)
+}
+
+func TestUnmarshalError(t *testing.T) {
+	var got []*account
+	if err := gocsv.UnmarshalString("name,cycle\nbad,1D2W\n", &got); err == nil {
+		t.Errorf("gocsv.UnmarshalString: no error for an unparseable Timespan")
+	}
+}
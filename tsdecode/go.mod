@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tsdecode
+
+go 1.18
+
+require (
+	github.com/go-viper/mapstructure/v2 v2.2.1
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
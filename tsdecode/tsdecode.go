@@ -0,0 +1,128 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tsdecode provides a mapstructure decode hook for timespan.Timespan
+// fields, as used when unmarshaling configuration with viper.
+//
+// It is a separate module so that the timespan package itself does not
+// depend upon mapstructure.
+package tsdecode
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"toolman.org/time/timespan/v2"
+)
+
+var (
+	timespanType    = reflect.TypeOf(timespan.Timespan{})
+	timespanPtrType = reflect.PtrTo(timespanType)
+)
+
+// An Option alters the behavior of the hook returned by DecodeHook.
+type Option func(*options)
+
+type options struct {
+	numericSeconds bool
+}
+
+// NumericSeconds returns an Option that allows integer and floating point
+// values to be decoded as a Timespan holding that many seconds. Without it,
+// only strings are converted.
+func NumericSeconds() Option {
+	return func(o *options) {
+		o.numericSeconds = true
+	}
+}
+
+// DecodeHook returns a mapstructure.DecodeHookFunc that converts strings to
+// timespan.Timespan or *timespan.Timespan values using timespan.ParseTimespan.
+// Values for any other target type are passed through untouched so the hook
+// may be combined with others using mapstructure.ComposeDecodeHookFunc.
+//
+// Parse errors are returned as-is; mapstructure prefixes them with the path
+// of the field being decoded.
+//
+// With viper, the hook is installed using:
+//
+//	v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		tsdecode.DecodeHook(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//	)))
+func DecodeHook(opts ...Option) mapstructure.DecodeHookFunc {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != timespanType && to != timespanPtrType {
+			return data, nil
+		}
+
+		ts, err := o.convert(data)
+		if err != nil || ts == nil {
+			return data, err
+		}
+
+		if to == timespanType {
+			return *ts, nil
+		}
+
+		return ts, nil
+	}
+}
+
+// convert returns the Timespan for data or nil if data is of a type the hook
+// doesn't handle.
+func (o *options) convert(data interface{}) (*timespan.Timespan, error) {
+	v := reflect.ValueOf(data)
+
+	switch v.Kind() {
+	case reflect.String:
+		return timespan.ParseTimespan(v.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if o.numericSeconds {
+			return fromSeconds(float64(v.Int()), data)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if o.numericSeconds {
+			return fromSeconds(float64(v.Uint()), data)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if o.numericSeconds {
+			return fromSeconds(v.Float(), data)
+		}
+	}
+
+	return nil, nil
+}
+
+func fromSeconds(secs float64, data interface{}) (*timespan.Timespan, error) {
+	ns := secs * float64(time.Second)
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return nil, fmt.Errorf("tsdecode: %v seconds overflows time.Duration", data)
+	}
+
+	return &timespan.Timespan{Duration: time.Duration(ns)}, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsdecode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"toolman.org/time/timespan/v2"
+)
+
+type config struct {
+	Retention timespan.Timespan
+	Grace     *timespan.Timespan
+	Timeout   time.Duration
+	Nested    struct {
+		Every *timespan.Timespan
+	}
+}
+
+func decode(t *testing.T, input map[string]interface{}, opts ...Option) (*config, error) {
+	t.Helper()
+
+	cfg := &config{}
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			DecodeHook(opts...),
+			mapstructure.StringToTimeDurationHookFunc(),
+		),
+		Result: cfg,
+	})
+	if err != nil {
+		t.Fatalf("mapstructure.NewDecoder: %v", err)
+	}
+
+	return cfg, dec.Decode(input)
+}
+
+func TestDecodeHook(t *testing.T) {
+	cfg, err := decode(t, map[string]interface{}{
+		"retention": "1Y6M",
+		"grace":     "-2D12h",
+		"timeout":   "30s",
+		"nested":    map[string]interface{}{"every": "1W"},
+	})
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if want := (&timespan.Timespan{Years: 1, Months: 6}); !cfg.Retention.Equal(want) {
+		t.Errorf("Retention == %v; Wanted %v", &cfg.Retention, want)
+	}
+
+	if want := (&timespan.Timespan{Days: -2, Duration: 12 * time.Hour}); !cfg.Grace.Equal(want) {
+		t.Errorf("Grace == %v; Wanted %v", cfg.Grace, want)
+	}
+
+	if want := 30 * time.Second; cfg.Timeout != want {
+		t.Errorf("Timeout == %v; Wanted %v", cfg.Timeout, want)
+	}
+
+	if want := (&timespan.Timespan{Weeks: 1}); !cfg.Nested.Every.Equal(want) {
+		t.Errorf("Nested.Every == %v; Wanted %v", cfg.Nested.Every, want)
+	}
+}
+
+func TestDecodeHookNumericSeconds(t *testing.T) {
+	input := map[string]interface{}{"retention": 90, "grace": 1.5}
+
+	if _, err := decode(t, input); err == nil {
+		t.Errorf("Decode without NumericSeconds succeeded; Wanted error")
+	}
+
+	cfg, err := decode(t, input, NumericSeconds())
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if want := (&timespan.Timespan{Duration: 90 * time.Second}); !cfg.Retention.Equal(want) {
+		t.Errorf("Retention == %v; Wanted %v", &cfg.Retention, want)
+	}
+
+	if want := (&timespan.Timespan{Duration: 1500 * time.Millisecond}); !cfg.Grace.Equal(want) {
+		t.Errorf("Grace == %v; Wanted %v", cfg.Grace, want)
+	}
+
+	if _, err := decode(t, map[string]interface{}{"grace": 1e300}, NumericSeconds()); err == nil {
+		t.Errorf("Decode of 1e300 seconds succeeded; Wanted overflow error")
+	}
+}
+
+func TestDecodeHookErrorPath(t *testing.T) {
+	_, err := decode(t, map[string]interface{}{
+		"nested": map[string]interface{}{"every": "1D2W"},
+	})
+	if err == nil {
+		t.Fatal("Decode succeeded; Wanted error")
+	}
+
+	if got := err.Error(); !strings.Contains(got, "Nested.Every") || !strings.Contains(got, "1D2W") {
+		t.Errorf("Decode error == %q; Wanted field path and input", got)
+	}
+}
+
+func ExampleDecodeHook() {
+	var cfg struct {
+		Retention *timespan.Timespan
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: DecodeHook(),
+		Result:     &cfg,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if err := dec.Decode(map[string]interface{}{"retention": "1Y6M"}); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(cfg.Retention)
+	// Output: 1Y6M
+}
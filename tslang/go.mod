@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tslang
+
+go 1.18
+
+require (
+	golang.org/x/text v0.14.0
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
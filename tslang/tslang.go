@@ -0,0 +1,166 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tslang renders timespan.Timespan values in a natural language
+// using golang.org/x/text, with CLDR plural rules choosing the form of each
+// unit; e.g. "1 año, 6 meses" in Spanish.
+//
+// It is a separate module so that the timespan package itself does not
+// depend upon golang.org/x/text.
+package tslang
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+	"toolman.org/time/timespan/v2"
+)
+
+// The units rendered by HumanizeLang, from largest to smallest. Each is also
+// the catalog key of its message.
+const (
+	years   = "%d years"
+	months  = "%d months"
+	weeks   = "%d weeks"
+	days    = "%d days"
+	hours   = "%d hours"
+	minutes = "%d minutes"
+	seconds = "%d seconds"
+)
+
+// The singular and plural form of each unit for each supported language.
+var unitTables = map[language.Tag]map[string][2]string{
+	language.English: {
+		years:   {"%d year", "%d years"},
+		months:  {"%d month", "%d months"},
+		weeks:   {"%d week", "%d weeks"},
+		days:    {"%d day", "%d days"},
+		hours:   {"%d hour", "%d hours"},
+		minutes: {"%d minute", "%d minutes"},
+		seconds: {"%d second", "%d seconds"},
+	},
+	language.Spanish: {
+		years:   {"%d año", "%d años"},
+		months:  {"%d mes", "%d meses"},
+		weeks:   {"%d semana", "%d semanas"},
+		days:    {"%d día", "%d días"},
+		hours:   {"%d hora", "%d horas"},
+		minutes: {"%d minuto", "%d minutos"},
+		seconds: {"%d segundo", "%d segundos"},
+	},
+	language.French: {
+		years:   {"%d an", "%d ans"},
+		months:  {"%d mois", "%d mois"},
+		weeks:   {"%d semaine", "%d semaines"},
+		days:    {"%d jour", "%d jours"},
+		hours:   {"%d heure", "%d heures"},
+		minutes: {"%d minute", "%d minutes"},
+		seconds: {"%d seconde", "%d secondes"},
+	},
+	language.German: {
+		years:   {"%d Jahr", "%d Jahre"},
+		months:  {"%d Monat", "%d Monate"},
+		weeks:   {"%d Woche", "%d Wochen"},
+		days:    {"%d Tag", "%d Tage"},
+		hours:   {"%d Stunde", "%d Stunden"},
+		minutes: {"%d Minute", "%d Minuten"},
+		seconds: {"%d Sekunde", "%d Sekunden"},
+	},
+}
+
+var (
+	// Languages lists the languages supported by HumanizeLang.
+	Languages = []language.Tag{
+		language.English, language.Spanish, language.French, language.German,
+	}
+
+	matcher = language.NewMatcher(Languages)
+	cat     = newCatalog()
+)
+
+func newCatalog() catalog.Catalog {
+	b := catalog.NewBuilder(catalog.Fallback(language.English))
+
+	for tag, units := range unitTables {
+		for key, forms := range units {
+			msg := plural.Selectf(1, "%d", plural.One, forms[0], plural.Other, forms[1])
+			if err := b.Set(tag, key, msg); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	return b
+}
+
+// HumanizeLang renders ts in the language best matching tag as a comma
+// separated list of its non-zero units (e.g. "1 year, 6 months, 2 hours"),
+// with each number formatted and each unit given the plural form required
+// by that language's CLDR rules. A Weeks member is rendered in weeks rather
+// than being folded into days. The Duration is broken down into hours,
+// minutes and seconds; any fraction of a second is dropped. A zero (or nil)
+// Timespan is rendered as zero seconds.
+//
+// An error is returned if tag doesn't match any of Languages.
+func HumanizeLang(ts *timespan.Timespan, tag language.Tag) (string, error) {
+	_, i, conf := matcher.Match(tag)
+	if conf == language.No {
+		return "", fmt.Errorf("tslang: unsupported language %v", tag)
+	}
+
+	p := message.NewPrinter(Languages[i], message.Catalog(cat))
+
+	var v timespan.Timespan
+	if ts != nil {
+		v = *ts
+	}
+
+	d := v.Duration
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+
+	var parts []string
+	for _, u := range []struct {
+		key string
+		n   int64
+	}{
+		{years, int64(v.Years)},
+		{months, int64(v.Months)},
+		{weeks, int64(v.Weeks)},
+		{days, int64(v.Days)},
+		{hours, int64(h)},
+		{minutes, int64(m)},
+		{seconds, int64(s)},
+	} {
+		if u.n != 0 {
+			parts = append(parts, p.Sprintf(u.key, u.n))
+		}
+	}
+
+	if len(parts) == 0 {
+		return p.Sprintf(seconds, 0), nil
+	}
+
+	return strings.Join(parts, ", "), nil
+}
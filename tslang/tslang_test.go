@@ -0,0 +1,69 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tslang
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+	"toolman.org/time/timespan/v2"
+)
+
+func TestHumanizeLang(t *testing.T) {
+	span := &timespan.Timespan{Years: 1, Months: 6}
+	big := &timespan.Timespan{Years: 1000, Weeks: 1, Duration: 90*time.Minute + time.Second + time.Millisecond}
+	neg := &timespan.Timespan{Years: -1, Days: -2}
+
+	cases := []struct {
+		tag  language.Tag
+		ts   *timespan.Timespan
+		want string
+	}{
+		{language.English, span, "1 year, 6 months"},
+		{language.English, big, "1,000 years, 1 week, 1 hour, 30 minutes, 1 second"},
+		{language.English, neg, "-1 year, -2 days"},
+		{language.English, nil, "0 seconds"},
+		{language.Spanish, span, "1 año, 6 meses"},
+		{language.Spanish, big, "1.000 años, 1 semana, 1 hora, 30 minutos, 1 segundo"},
+		{language.Spanish, &timespan.Timespan{}, "0 segundos"},
+		{language.French, span, "1 an, 6 mois"},
+		{language.French, &timespan.Timespan{Days: 2, Duration: time.Second}, "2 jours, 1 seconde"},
+		{language.French, nil, "0 seconde"},
+		{language.German, span, "1 Jahr, 6 Monate"},
+		{language.German, &timespan.Timespan{Weeks: 2, Days: 1}, "2 Wochen, 1 Tag"},
+		{language.MustParse("es-MX"), span, "1 año, 6 meses"},
+		{language.MustParse("de-CH"), span, "1 Jahr, 6 Monate"},
+	}
+
+	for _, tc := range cases {
+		got, err := HumanizeLang(tc.ts, tc.tag)
+		if err != nil {
+			t.Errorf("HumanizeLang(%v, %v) returned error: %v", tc.ts, tc.tag, err)
+		} else if got != tc.want {
+			t.Errorf("HumanizeLang(%v, %v) == %q; Wanted %q", tc.ts, tc.tag, got, tc.want)
+		}
+	}
+}
+
+func TestHumanizeLangUnsupported(t *testing.T) {
+	ts := &timespan.Timespan{Days: 1}
+
+	if got, err := HumanizeLang(ts, language.Japanese); err == nil {
+		t.Errorf("HumanizeLang(%v, %v) == %q; Wanted an error", ts, language.Japanese, got)
+	}
+}
@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tspb
+
+go 1.20
+
+require (
+	google.golang.org/protobuf v1.34.2
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
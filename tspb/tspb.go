@@ -0,0 +1,89 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tspb converts between timespan.Timespan values and the
+// google.protobuf.Duration well-known type.
+//
+// It is a separate module so that the timespan package itself does not
+// depend upon protobuf.
+package tspb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"toolman.org/time/timespan/v2"
+)
+
+// ErrCalendarSpan is returned by ToProtoDuration when asked to convert a
+// Timespan with non-zero Years, Months, Weeks or Days without a reference time.
+var ErrCalendarSpan = errors.New("tspb: calendar-based Timespan requires a reference time")
+
+// FromProtoDuration returns a new Timespan whose Duration is equal to d. Since
+// a protobuf Duration may hold about 10,000 years while a time.Duration is
+// limited to about 292, an error is returned if d is too large (or too
+// small) to be represented without loss. An error is also returned if d is
+// nil or otherwise invalid.
+func FromProtoDuration(d *durationpb.Duration) (*timespan.Timespan, error) {
+	if err := d.CheckValid(); err != nil {
+		return nil, err
+	}
+
+	secs, nanos := d.GetSeconds(), int64(d.GetNanos())
+
+	// CheckValid ensures secs and nanos have the same sign
+	ns := secs * int64(time.Second)
+	if secs > math.MaxInt64/int64(time.Second) || secs < math.MinInt64/int64(time.Second) ||
+		(nanos > 0 && ns+nanos < ns) || (nanos < 0 && ns+nanos > ns) {
+		return nil, fmt.Errorf("tspb: %v overflows time.Duration", d)
+	}
+
+	return &timespan.Timespan{Duration: time.Duration(ns + nanos)}, nil
+}
+
+// ToProtoDuration returns a protobuf Duration equal to the elapsed time that
+// ts resolves to when applied at Time at. If ts has only a Duration, at is
+// not consulted and may be the zero time.Time; otherwise, a zero value for at
+// yields ErrCalendarSpan since calendar units have no fixed length. An error
+// is also returned if the resolved span does not fit in a time.Duration.
+//
+// A nil Timespan is treated as a zero span.
+func ToProtoDuration(ts *timespan.Timespan, at time.Time) (*durationpb.Duration, error) {
+	if ts == nil {
+		return durationpb.New(0), nil
+	}
+
+	if !ts.HasCalendar() {
+		return durationpb.New(ts.Duration), nil
+	}
+
+	if at.IsZero() {
+		return nil, ErrCalendarSpan
+	}
+
+	end := ts.From(at)
+	d := end.Sub(at)
+
+	// time.Time's Sub saturates rather than overflowing
+	if !at.Add(d).Equal(end) {
+		return nil, fmt.Errorf("tspb: %v from %v overflows time.Duration", ts, at)
+	}
+
+	return durationpb.New(d), nil
+}
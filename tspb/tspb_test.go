@@ -0,0 +1,128 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tspb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"toolman.org/time/timespan/v2"
+)
+
+func TestFromProtoDuration(t *testing.T) {
+	cases := []struct {
+		d    *durationpb.Duration
+		want time.Duration
+	}{
+		{&durationpb.Duration{Seconds: 90, Nanos: 1}, 90*time.Second + 1},
+		{&durationpb.Duration{Seconds: -90, Nanos: -999999999}, -91*time.Second + 1},
+		{&durationpb.Duration{Nanos: -1}, -1},
+		{&durationpb.Duration{}, 0},
+		{durationpb.New(math.MaxInt64), math.MaxInt64},
+		{durationpb.New(math.MinInt64), math.MinInt64},
+	}
+
+	for _, tc := range cases {
+		got, err := FromProtoDuration(tc.d)
+		if err != nil {
+			t.Errorf("FromProtoDuration(%v) returned error: %v", tc.d, err)
+			continue
+		}
+
+		if want := (&timespan.Timespan{Duration: tc.want}); !got.Equal(want) {
+			t.Errorf("FromProtoDuration(%v) == %v; Wanted %v", tc.d, got, want)
+		}
+	}
+}
+
+func TestFromProtoDurationBad(t *testing.T) {
+	cases := []*durationpb.Duration{
+		nil,
+		{Seconds: 1, Nanos: -1}, // mismatched signs
+		{Nanos: 1e9},            // nanos out of range
+		{Seconds: math.MaxInt64 / 1000000000, Nanos: 999999999},
+		{Seconds: math.MinInt64/1000000000 - 1},
+		{Seconds: 315576000000}, // 10,000 years
+	}
+
+	for _, d := range cases {
+		if got, err := FromProtoDuration(d); err == nil {
+			t.Errorf("FromProtoDuration(%v) == %v; Wanted an error", d, got)
+		}
+	}
+}
+
+func TestToProtoDuration(t *testing.T) {
+	feb := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		ts   *timespan.Timespan
+		at   time.Time
+		want *durationpb.Duration
+	}{
+		{&timespan.Timespan{Duration: 90*time.Second + 1}, time.Time{}, &durationpb.Duration{Seconds: 90, Nanos: 1}},
+		{&timespan.Timespan{Duration: -1500 * time.Millisecond}, time.Time{}, &durationpb.Duration{Seconds: -1, Nanos: -500000000}},
+		{nil, time.Time{}, &durationpb.Duration{}},
+		{&timespan.Timespan{Months: 1}, feb, &durationpb.Duration{Seconds: 28 * 86400}},
+		{&timespan.Timespan{Months: -1, Duration: -1}, feb, &durationpb.Duration{Seconds: -31 * 86400, Nanos: -1}},
+	}
+
+	for _, tc := range cases {
+		got, err := ToProtoDuration(tc.ts, tc.at)
+		if err != nil {
+			t.Errorf("ToProtoDuration(%v, %v) returned error: %v", tc.ts, tc.at, err)
+			continue
+		}
+
+		if got.GetSeconds() != tc.want.GetSeconds() || got.GetNanos() != tc.want.GetNanos() {
+			t.Errorf("ToProtoDuration(%v, %v) == %v; Wanted %v", tc.ts, tc.at, got, tc.want)
+		}
+
+		if err := got.CheckValid(); err != nil {
+			t.Errorf("ToProtoDuration(%v, %v) is invalid: %v", tc.ts, tc.at, err)
+		}
+	}
+}
+
+func TestToProtoDurationBad(t *testing.T) {
+	if got, err := ToProtoDuration(&timespan.Timespan{Days: 1}, time.Time{}); err != ErrCalendarSpan {
+		t.Errorf("ToProtoDuration without reference time == (%v, %v); Wanted %v", got, err, ErrCalendarSpan)
+	}
+
+	at := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := ToProtoDuration(&timespan.Timespan{Years: 300}, at); err == nil {
+		t.Errorf("ToProtoDuration(300Y) == %v; Wanted an error", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{0, 1, -1, 123456789 * time.Microsecond, -36 * time.Hour, math.MaxInt64, math.MinInt64} {
+		ts := &timespan.Timespan{Duration: d}
+
+		pd, err := ToProtoDuration(ts, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := FromProtoDuration(pd)
+		if err != nil || !got.Equal(ts) {
+			t.Errorf("round-trip of %v == (%v, %v)", ts, got, err)
+		}
+	}
+}
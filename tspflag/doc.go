@@ -0,0 +1,22 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tspflag holds the integration tests for using a *timespan.Timespan
+// as a pflag.Value, which are run against github.com/spf13/pflag itself.
+//
+// It is a separate module so that the timespan package itself does not
+// depend upon pflag.
+package tspflag
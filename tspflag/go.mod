@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tspflag
+
+go 1.18
+
+require (
+	github.com/spf13/pflag v1.0.10
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tspflag
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"toolman.org/time/timespan/v2"
+)
+
+// Ensure *timespan.Timespan implements pflag.Value
+var _ pflag.Value = (*timespan.Timespan)(nil)
+
+func TestFlagSetVar(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	var ttl, grace timespan.Timespan
+	fs.Var(&ttl, "ttl", "time to live")
+	fs.VarP(&grace, "grace", "g", "grace period")
+
+	if err := fs.Parse([]string{"--ttl=30D", "-g", "-2D12h"}); err != nil {
+		t.Fatalf("fs.Parse: %v", err)
+	}
+
+	if want := (timespan.Timespan{Days: 30}); ttl != want {
+		t.Errorf("ttl == %v; Wanted %v", &ttl, &want)
+	}
+
+	if want := (timespan.Timespan{Days: -2, Duration: 12 * time.Hour}); grace != want {
+		t.Errorf("grace == %v; Wanted %v", &grace, &want)
+	}
+
+	if got, want := fs.Lookup("ttl").Value.Type(), "timespan"; got != want {
+		t.Errorf("Value.Type() == %q; Wanted %q", got, want)
+	}
+}
+
+func TestFlagSetUsage(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	ttl := &timespan.Timespan{Weeks: 1}
+	fs.Var(ttl, "ttl", "time to live")
+
+	if got, want := fs.FlagUsages(), "--ttl timespan"; !strings.Contains(got, want) {
+		t.Errorf("FlagUsages() == %q; Wanted it to contain %q", got, want)
+	}
+
+	if got, want := fs.FlagUsages(), `(default 1W)`; !strings.Contains(got, want) {
+		t.Errorf("FlagUsages() == %q; Wanted it to contain %q", got, want)
+	}
+}
+
+func TestFlagSetBadValue(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.SetOutput(new(strings.Builder))
+
+	ttl := timespan.Timespan{Days: 1}
+	fs.Var(&ttl, "ttl", "time to live")
+
+	err := fs.Parse([]string{"--ttl=1D2W"})
+	if err == nil {
+		t.Fatalf("fs.Parse(--ttl=1D2W) == nil; Wanted an error")
+	}
+
+	if !strings.Contains(err.Error(), "ttl") {
+		t.Errorf("fs.Parse(--ttl=1D2W) == %q; Wanted it to name the flag", err)
+	}
+
+	if want := (timespan.Timespan{Days: 1}); ttl != want {
+		t.Errorf("ttl == %v after failed Parse; Wanted %v", &ttl, &want)
+	}
+}
@@ -0,0 +1,10 @@
+module toolman.org/time/timespan/v2/tsyaml
+
+go 1.18
+
+require (
+	gopkg.in/yaml.v3 v3.0.1
+	toolman.org/time/timespan/v2 v2.0.0
+)
+
+replace toolman.org/time/timespan/v2 => ../
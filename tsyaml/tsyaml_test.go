@@ -0,0 +1,101 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tsyaml
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"toolman.org/time/timespan/v2"
+)
+
+type config struct {
+	TTL     *timespan.Timespan `yaml:"ttl"`
+	Grace   *timespan.Timespan `yaml:"grace"`
+	Every   timespan.Timespan  `yaml:"every"`
+	Missing *timespan.Timespan `yaml:"missing"`
+}
+
+func TestDecode(t *testing.T) {
+	var cfg config
+	input := "ttl: 30D\ngrace: -2D12h\nevery: 1W\n"
+
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal(%q): %v", input, err)
+	}
+
+	if want := (&timespan.Timespan{Days: 30}); !cfg.TTL.Equal(want) {
+		t.Errorf("TTL == %v; Wanted %v", cfg.TTL, want)
+	}
+
+	if want := (&timespan.Timespan{Days: -2, Duration: 12 * time.Hour}); !cfg.Grace.Equal(want) {
+		t.Errorf("Grace == %v; Wanted %v", cfg.Grace, want)
+	}
+
+	if want := (&timespan.Timespan{Weeks: 1}); !cfg.Every.Equal(want) {
+		t.Errorf("Every == %v; Wanted %v", &cfg.Every, want)
+	}
+
+	if cfg.Missing != nil {
+		t.Errorf("Missing == %v; Wanted nil", cfg.Missing)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := config{
+		TTL:   &timespan.Timespan{Days: 30},
+		Grace: &timespan.Timespan{Years: 1, Months: 6, Duration: 2 * time.Hour},
+		Every: timespan.Timespan{Weeks: 1, Days: 2},
+	}
+
+	out, err := yaml.Marshal(&want)
+	if err != nil {
+		t.Fatalf("yaml.Marshal(%+v): %v", want, err)
+	}
+
+	if !strings.Contains(string(out), "ttl: 30D\n") {
+		t.Errorf("yaml.Marshal(%+v) == %q; Wanted a \"ttl: 30D\" scalar", want, out)
+	}
+
+	if !strings.Contains(string(out), "every: 1W2D\n") {
+		t.Errorf("yaml.Marshal(%+v) == %q; Wanted an \"every: 1W2D\" scalar", want, out)
+	}
+
+	if !strings.Contains(string(out), "missing: null\n") {
+		t.Errorf("yaml.Marshal(%+v) == %q; Wanted a null \"missing\" field", want, out)
+	}
+
+	var got config
+	if err := yaml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal(%q): %v", out, err)
+	}
+
+	if !got.TTL.Equal(want.TTL) || !got.Grace.Equal(want.Grace) || !got.Every.Equal(&want.Every) || got.Missing != nil {
+		t.Errorf("YAML round-trip mismatch: Got %+v; Wanted %+v", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, input := range []string{"ttl: 1D2W\n", "ttl: [1D]\n", "ttl: {days: 1}\n"} {
+		var cfg config
+		if err := yaml.Unmarshal([]byte(input), &cfg); err == nil {
+			t.Errorf("yaml.Unmarshal(%q): no error; Wanted one", input)
+		}
+	}
+}
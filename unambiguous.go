@@ -0,0 +1,92 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"strings"
+	"unicode"
+)
+
+// unambiguousUnits maps each unit accepted by ParseUnambiguous to its
+// equivalent in the syntax accepted by ParseTimespan.
+var unambiguousUnits = map[string]string{
+	"yr":  "Y",
+	"mo":  "M",
+	"wk":  "W",
+	"d":   "D",
+	"h":   "h",
+	"min": "m",
+	"s":   "s",
+	"ms":  "ms",
+	"us":  "us",
+	"µs":  "µs",
+	"ns":  "ns",
+}
+
+// ParseUnambiguous is like ParseTimespan except that it accepts a more
+// verbose set of units that can't be confused with one another. Calendar
+// units are "yr", "mo", "wk" and "d" while clock units are "h", "min", "s",
+// "ms", "us" (or "µs") and "ns". Notably, neither "M" nor "m" is accepted,
+// so "1mo" (one month) and "1min" (one minute) can't be mistaken for each
+// other. Components may be separated by spaces, e.g. "1yr 6mo 90min".
+//
+// Calendar units must appear before any clock units and otherwise follow the
+// same rules as ParseTimespan, including its handling of signs. Any error is
+// a *ParseError whose Input is s.
+func ParseUnambiguous(s string) (*Timespan, error) {
+	var cal, clock strings.Builder
+
+	for rest := strings.TrimSpace(s); rest != ""; rest = strings.TrimSpace(rest) {
+		i := strings.IndexFunc(rest, unicode.IsLetter)
+		if i < 0 {
+			return nil, timespanError(UnrecognizedMagErr, "missing unit after %q", rest).withTimespan(s)
+		}
+
+		j := strings.IndexFunc(rest[i:], func(r rune) bool { return !unicode.IsLetter(r) })
+		if j < 0 {
+			j = len(rest)
+		} else {
+			j += i
+		}
+
+		num, unit := strings.TrimSpace(rest[:i]), rest[i:j]
+		rest = rest[j:]
+
+		u, ok := unambiguousUnits[unit]
+		if !ok {
+			return nil, timespanError(UnrecognizedMagErr, "unrecognized unit: %q", unit).withTimespan(s)
+		}
+
+		if strings.IndexAny(u, magOrder) < 0 {
+			clock.WriteString(num + u)
+			continue
+		}
+
+		if clock.Len() > 0 {
+			return nil, timespanError(MagnOutOfOrderErr, "calendar unit %q specified after clock units", unit).withTimespan(s)
+		}
+
+		cal.WriteString(num + u)
+	}
+
+	ts, err := ParseTimespan(cal.String() + clock.String())
+	if pe, ok := err.(*ParseError); ok {
+		return nil, pe.withTimespan(s)
+	}
+
+	return ts, err
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUnambiguousGood(t *testing.T) {
+	data := []testdata{
+		{str: "1yr2mo", want: &Timespan{1, 2, 0, 0, 0}},
+		{str: "1mo", want: &Timespan{0, 1, 0, 0, 0}},
+		{str: "1min", want: &Timespan{0, 0, 0, 0, time.Minute}},
+		{str: "2wk3d", want: &Timespan{0, 0, 2, 3, 0}},
+		{str: "1yr 6mo 90min", want: &Timespan{1, 6, 0, 0, 90 * time.Minute}},
+		{str: " 3d 4h ", want: &Timespan{0, 0, 0, 3, 4 * time.Hour}},
+		{str: "1.5h", want: &Timespan{0, 0, 0, 0, 90 * time.Minute}},
+		{str: "1h30min15s", want: &Timespan{0, 0, 0, 0, time.Hour + 30*time.Minute + 15*time.Second}},
+		{str: "500ms", want: &Timespan{0, 0, 0, 0, 500 * time.Millisecond}},
+		{str: "10us", want: &Timespan{0, 0, 0, 0, 10 * time.Microsecond}},
+		{str: "10µs", want: &Timespan{0, 0, 0, 0, 10 * time.Microsecond}},
+		{str: "3ns", want: &Timespan{0, 0, 0, 0, 3}},
+		{str: "-1yr2mo", want: &Timespan{-1, -2, 0, 0, 0}},
+		{str: "-1yr+2mo", want: &Timespan{-1, 2, 0, 0, 0}},
+		{str: "2mo-1d", want: &Timespan{0, 2, 0, -1, 0}},
+	}
+
+	for _, td := range data {
+		got, err := ParseUnambiguous(td.str)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if *got != *td.want {
+			t.Errorf("Mismatch parsing unambiguous Timespan %q  Got:%+v  Wanted:%+v", td.str, got, td.want)
+		}
+	}
+}
+
+func TestParseUnambiguousBad(t *testing.T) {
+	data := []testdata{
+		{str: "1M", etype: UnrecognizedMagErr},
+		{str: "1m", etype: UnrecognizedMagErr},
+		{str: "1y", etype: UnrecognizedMagErr},
+		{str: "1D", etype: UnrecognizedMagErr},
+		{str: "12", etype: UnrecognizedMagErr},
+		{str: "1h2d", etype: MagnOutOfOrderErr},
+		{str: "2mo1yr", etype: MagnOutOfOrderErr},
+		{str: "1d1d", etype: MagnRestatedErr},
+		{str: "yr", etype: MissingCoefErr},
+		{str: "1.5mo", etype: UnrecognizedMagErr},
+		{str: "", etype: EmptyInputErr},
+	}
+
+	for _, td := range data {
+		_, err := ParseUnambiguous(td.str)
+		if err == nil {
+			t.Errorf("No error found parsing invalid unambiguous Timespan %q: wanted:%v", td.str, td.etype)
+			continue
+		}
+
+		tse, ok := err.(*ParseError)
+		if !ok {
+			t.Fatalf("Error returned while parsing invalid unambiguous Timespan %q is not a ParseError: %v", td.str, err)
+		}
+
+		if tse.Type() != td.etype {
+			t.Errorf("Error mismatch parsing invalid unambiguous Timespan %q: got %v; wanted %v", td.str, tse.Type(), td.etype)
+		}
+
+		if tse.Input() != td.str {
+			t.Errorf("Input mismatch for ParseError from %q: got %q", td.str, tse.Input())
+		}
+	}
+}
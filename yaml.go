@@ -0,0 +1,48 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+// The YAML hooks below use the function-based Unmarshaler signature that is
+// honored by both gopkg.in/yaml.v2 and gopkg.in/yaml.v3. This allows
+// Timespan values to be rendered as scalars (e.g. "ttl: 30D") without adding
+// a dependency on either package. The tsyaml module exercises them against
+// yaml.v3 itself.
+
+// MarshalYAML renders ts as a YAML scalar using its String method. It has a
+// value receiver, unlike String, because the yaml packages don't take the
+// address of a field when encoding; a pointer receiver would leave Timespan
+// (as opposed to *Timespan) fields encoded as a mapping of their members.
+func (ts Timespan) MarshalYAML() (interface{}, error) {
+	return ts.String(), nil
+}
+
+// UnmarshalYAML decodes a YAML scalar into ts using ParseTimespan. An empty
+// scalar (as produced by MarshalYAML for the zero Timespan) is decoded as the
+// zero Timespan.
+func (ts *Timespan) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	if s == "" {
+		*ts = Timespan{}
+		return nil
+	}
+
+	return ParseTimespanInto(s, ts)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2017 Timothy E. Peoples
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timespan
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// scalar returns an unmarshal function, like that passed to UnmarshalYAML by
+// the yaml package, which decodes the given scalar value.
+func scalar(v string) func(interface{}) error {
+	return func(out interface{}) error {
+		sp, ok := out.(*string)
+		if !ok {
+			return errors.New("scalar can only be decoded into a *string")
+		}
+		*sp = v
+		return nil
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	cases := []struct {
+		ts   Timespan
+		want string
+	}{
+		{Timespan{Days: 30}, "30D"},
+		{Timespan{Years: 1, Months: 6, Duration: 2 * time.Hour}, "1Y6M2h0m0s"},
+		{Timespan{}, ""},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.ts.MarshalYAML()
+		if err != nil {
+			t.Errorf("(%v).MarshalYAML() returned error: %v", &tc.ts, err)
+			continue
+		}
+
+		if got != tc.want {
+			t.Errorf("(%v).MarshalYAML() == %#v; Wanted %#v", &tc.ts, got, tc.want)
+		}
+
+		var back Timespan
+		if err := back.UnmarshalYAML(scalar(got.(string))); err != nil {
+			t.Errorf("UnmarshalYAML(%q) returned error: %v", got, err)
+		} else if !back.Equal(&tc.ts) {
+			t.Errorf("YAML round-trip mismatch: Got %v; Wanted %v", &back, &tc.ts)
+		}
+	}
+}
+
+func TestUnmarshalYAMLErrors(t *testing.T) {
+	want := Timespan{Days: 1}
+
+	cases := map[string]func(interface{}) error{
+		"bad span":  scalar("1D2W"),
+		"not a str": func(interface{}) error { return errors.New("cannot unmarshal !!map into string") },
+	}
+
+	for name, unmarshal := range cases {
+		got := want
+		if err := got.UnmarshalYAML(unmarshal); err == nil {
+			t.Errorf("%s: no error from UnmarshalYAML", name)
+		}
+
+		if !got.Equal(&want) {
+			t.Errorf("%s: UnmarshalYAML modified Timespan on error: %v", name, &got)
+		}
+	}
+}